@@ -21,10 +21,14 @@ var apigatewayCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = apigateway.NewAPIGateway(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = apigateway.NewAPIGateway(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
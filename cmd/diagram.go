@@ -16,11 +16,16 @@ var diagramCmd = &cobra.Command{
 		configFile, _ := cmd.Flags().GetString(flagConfig)
 		output, _ := cmd.Flags().GetString(flagOutput)
 
-		if err := diagram.NewDiagram(diagramFilename, configFile, output).Build(); err != nil {
-			printErrorAndExit(err)
+		fs := newFileSystem(cmd)
+
+		err := diagram.NewDiagram(diagramFilename, configFile, output, newGeneratorOptions(cmd, fs)...).Build()
+		if err != nil {
+			printBuildErrorAndExit(err)
 		}
 
 		fmtcolor.White.Printf("Configuration file '%s' has been generated successfully\n", output)
+
+		printDryRun(fs)
 	},
 }
 
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"os"
 	"path"
 
 	"github.com/spf13/cobra"
@@ -81,17 +80,15 @@ var diffCmd = &cobra.Command{
 
 		dotFilename := "diff.dot"
 
-		dotfile, err := os.Create(path.Join(".", dotFilename))
-		if err != nil {
-			printErrorAndExit(err)
-		}
-		defer dotfile.Close()
+		fs := newFileSystem(cmd)
 
-		if _, err := dotfile.WriteString(dotContent); err != nil {
+		if err := fs.WriteFile(path.Join(".", dotFilename), []byte(dotContent)); err != nil {
 			printErrorAndExit(err)
 		}
 
 		fmtcolor.White.Println("The graphviz dot file has been generated successfully.")
+
+		printDryRun(fs)
 	},
 }
 
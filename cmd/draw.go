@@ -31,10 +31,14 @@ var drawCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = draw.NewDraw(workdirs, files, configFilename, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = draw.NewDraw(workdirs, files, configFilename, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+)
+
+// newFileSystem returns the file system used by the generators. In dry-run mode, the files are kept in memory. The
+// hand edits of existing Go and Terraform files are preserved unless the force flag is set.
+func newFileSystem(cmd *cobra.Command) filesystem.FileSystem {
+	var fs filesystem.FileSystem = filesystem.NewOS()
+	if isFlagSet(cmd, flagDryRun) {
+		fs = filesystem.NewMemory()
+	}
+
+	return filesystem.NewPreserve(fs, isFlagSet(cmd, flagForce), ".go", ".tf", ".py", ".js", ".txt", ".json")
+}
+
+// printBuildErrorAndExit prints the files skipped to keep their hand edits, and exits when anything else has failed.
+func printBuildErrorAndExit(err error) {
+	skipped, err := filesystem.SplitSkipped(err)
+
+	printSkipped(skipped, "")
+
+	if err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+// printSkipped prints the files skipped to keep their hand edits, along with how to overwrite them.
+func printSkipped(skipped []error, indent string) {
+	for _, err := range skipped {
+		fmtcolor.Yellow.Printf("%s%s. Use --force to overwrite it\n", indent, err)
+	}
+}
+
+func isFlagSet(cmd *cobra.Command, name string) bool {
+	flag := cmd.Flag(name)
+
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// printDryRun prints what would be written to disk, along with the unified diff of every changed file. It does nothing
+// when the files have already been written.
+func printDryRun(fs filesystem.FileSystem) {
+	for {
+		preserve, ok := fs.(*filesystem.Preserve)
+		if !ok {
+			break
+		}
+
+		fs = preserve.Unwrap()
+	}
+
+	memory, ok := fs.(*filesystem.Memory)
+	if !ok {
+		return
+	}
+
+	changes, err := memory.Changes()
+	if err != nil {
+		printErrorAndExit(err)
+		return
+	}
+
+	fmt.Println()
+	fmtcolor.White.Println("Dry run, nothing has been written:")
+
+	for _, change := range changes {
+		switch change.Status {
+		case filesystem.StatusNew:
+			fmtcolor.Green.Printf("  + %-10s %s\n", change.Status, change.Path)
+		case filesystem.StatusChanged:
+			fmtcolor.Yellow.Printf("  ~ %-10s %s\n", change.Status, change.Path)
+		case filesystem.StatusUnchanged:
+			fmtcolor.White.Printf("  = %-10s %s\n", change.Status, change.Path)
+		}
+	}
+
+	for _, change := range changes {
+		if change.Diff != "" {
+			fmt.Println()
+			fmt.Print(change.Diff)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/dynamodb"
+)
+
+// dynamodbCmd represents the dynamodb command.
+var dynamodbCmd = &cobra.Command{
+	Use:   "dynamodb",
+	Short: "Manage DynamoDB",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		fs := newFileSystem(cmd)
+
+		err = dynamodb.NewDynamoDB(config, output, newGeneratorOptions(cmd, fs)...).Build()
+		if err != nil {
+			printBuildErrorAndExit(err)
+		}
+
+		printDryRun(fs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dynamodbCmd)
+
+	dynamodbCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./dynamodb.config.yaml")
+	dynamodbCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = dynamodbCmd.MarkFlagRequired(flagConfig)
+	_ = dynamodbCmd.MarkFlagRequired(flagOutput)
+}
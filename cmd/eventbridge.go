@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/eventbridge"
+)
+
+// eventbridgeCmd represents the eventbridge command.
+var eventbridgeCmd = &cobra.Command{
+	Use:   "eventbridge",
+	Short: "Manage EventBridge",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		fs := newFileSystem(cmd)
+
+		err = eventbridge.NewEventBridge(config, output, newGeneratorOptions(cmd, fs)...).Build()
+		if err != nil {
+			printBuildErrorAndExit(err)
+		}
+
+		printDryRun(fs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventbridgeCmd)
+
+	eventbridgeCmd.Flags().StringP(flagConfig, "c", "",
+		"Path to the configuration file. For example: ./eventbridge.config.yaml")
+	eventbridgeCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = eventbridgeCmd.MarkFlagRequired(flagConfig)
+	_ = eventbridgeCmd.MarkFlagRequired(flagOutput)
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/apigateway"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/dynamodb"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/eventbridge"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/kinesis"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/lambda"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/module"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/pipeline"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/s3"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/sns"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/sqs"
+)
+
+// generateCmd represents the generate command.
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Run every code generator for a config",
+	Run: func(cmd *cobra.Command, _ []string) {
+		configFileName, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		stackName, err := cmd.Flags().GetString(flagStack)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		only, err := cmd.Flags().GetStringSlice(flagOnly)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		yamlConfig, err := config.NewYAML(configFileName, newYAMLOptions(cmd)...).Parse()
+		if err != nil {
+			printErrorAndExit(fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err))
+			return
+		}
+
+		if stackName == "" {
+			stackName = defaultStackName(configFileName)
+		}
+
+		fs := newFileSystem(cmd)
+
+		results, err := newGeneratePipeline(yamlConfig, configFileName, output, stackName,
+			newGeneratorOptions(cmd, fs)...).Run(only)
+
+		printGenerateSummary(results)
+		printDryRun(fs)
+
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+
+	generateCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./diagram.yaml")
+	generateCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+	generateCmd.Flags().StringP(flagStack, "s", "",
+		"Name of the stack. Default: the name of the folder that contains the configuration file")
+	generateCmd.Flags().StringSlice(flagOnly, nil,
+		"Comma-separated list of generators to run. For example: lambda,sqs")
+
+	_ = generateCmd.MarkFlagRequired(flagConfig)
+	_ = generateCmd.MarkFlagRequired(flagOutput)
+}
+
+// newGeneratePipeline returns the generators in the same order as the code guide. The API Gateway generator already
+// creates the stack folder, so it receives the root output. The module generator scans what the others have rendered,
+// so it runs last.
+func newGeneratePipeline(
+	yamlConfig *config.Config, configFileName, output, stackName string, opts ...generators.Option,
+) *pipeline.Pipeline {
+	stackOutput := path.Join(output, stackName)
+
+	return pipeline.NewPipeline(
+		pipeline.Step{
+			Name:    apigatewayCmd.Use,
+			Builder: apigateway.NewAPIGateway(configFileName, output, opts...),
+			Skip:    len(yamlConfig.APIGateways) == 0,
+		},
+		pipeline.Step{
+			Name:    dynamodbCmd.Use,
+			Builder: dynamodb.NewDynamoDB(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.DynamoDBs) == 0,
+		},
+		pipeline.Step{
+			Name:    eventbridgeCmd.Use,
+			Builder: eventbridge.NewEventBridge(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.EventBridges) == 0,
+		},
+		pipeline.Step{
+			Name:    kinesisCmd.Use,
+			Builder: kinesis.NewKinesis(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.Kinesis) == 0,
+		},
+		pipeline.Step{
+			Name:    lambdaCmd.Use,
+			Builder: lambda.NewLambda(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.Lambdas) == 0,
+		},
+		pipeline.Step{
+			Name:    s3Cmd.Use,
+			Builder: s3.NewS3(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.Buckets) == 0,
+		},
+		pipeline.Step{
+			Name:    snsCmd.Use,
+			Builder: sns.NewSNS(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.SNSs) == 0,
+		},
+		pipeline.Step{
+			Name:    sqsCmd.Use,
+			Builder: sqs.NewSQS(configFileName, stackOutput, opts...),
+			Skip:    len(yamlConfig.SQSs) == 0,
+		},
+		pipeline.Step{
+			Name:    moduleCmd.Use,
+			Builder: module.NewModule(configFileName, output, stackName, opts...),
+		},
+	)
+}
+
+func defaultStackName(configFileName string) string {
+	absConfigFileName, err := filepath.Abs(configFileName)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Base(filepath.Dir(absConfigFileName))
+}
+
+func printGenerateSummary(results []pipeline.Result) {
+	if len(results) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmtcolor.White.Println("Summary:")
+
+	for _, result := range results {
+		switch result.Status {
+		case pipeline.StatusGenerated:
+			fmtcolor.Green.Printf("  ✔ %-12s %s\n", result.Name, result.Status)
+		case pipeline.StatusSkipped:
+			fmtcolor.Yellow.Printf("  - %-12s %s\n", result.Name, result.Status)
+		case pipeline.StatusFailed:
+			fmtcolor.Red.Printf("  ✘ %-12s %s: %s\n", result.Name, result.Status, result.Err)
+		}
+
+		printSkipped(result.Skipped, "      ")
+	}
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Run(t *testing.T) {
+	type args struct {
+		configFile string
+		output     string
+		stack      string
+		only       string
+		env        string
+		dryRun     bool
+	}
+
+	tests := []struct {
+		name             string
+		args             args
+		setup            func() (tearDown func())
+		extraValidations func(testing.TB)
+	}{
+		{
+			name: "happy path",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.yaml"),
+				output:     path.Join(testOutput, "generate"),
+				stack:      "mystack",
+			},
+			extraValidations: func(tb testing.TB) {
+				modPath := path.Join(testOutput, "generate", "mystack", "mod")
+				require.FileExists(tb, path.Join(modPath, "sqs.tf"))
+				require.FileExists(tb, path.Join(modPath, "exampleReceiver.tf"))
+				require.FileExists(tb, path.Join(modPath, "vars.tf"))
+				require.FileExists(tb, path.Join(modPath, "outputs.tf"))
+			},
+		},
+		{
+			name: "only the selected generators",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.yaml"),
+				output:     path.Join(testOutput, "only"),
+				stack:      "mystack",
+				only:       "sqs",
+			},
+			extraValidations: func(tb testing.TB) {
+				modPath := path.Join(testOutput, "only", "mystack", "mod")
+				require.FileExists(tb, path.Join(modPath, "sqs.tf"))
+				require.NoFileExists(tb, path.Join(modPath, "exampleReceiver.tf"))
+			},
+		},
+		{
+			name: "dry run should not write any file",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.yaml"),
+				output:     path.Join(testOutput, "dryrun"),
+				stack:      "mystack",
+				dryRun:     true,
+			},
+			extraValidations: func(tb testing.TB) {
+				require.NoDirExists(tb, path.Join(testOutput, "dryrun"))
+			},
+		},
+		{
+			name: "unknown generator",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.yaml"),
+				output:     path.Join(testOutput, "unknown"),
+				stack:      "mystack",
+				only:       "unknown",
+			},
+			setup: func() (tearDown func()) {
+				osExit = func(code int) {
+					require.Equal(t, 1, code)
+				}
+
+				return func() {
+					osExit = os.Exit
+				}
+			},
+			extraValidations: func(tb testing.TB) {
+				require.NoDirExists(tb, path.Join(testOutput, "unknown"))
+			},
+		},
+		{
+			name: "unknown environment",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.yaml"),
+				output:     path.Join(testOutput, "env"),
+				stack:      "mystack",
+				env:        "prod",
+			},
+			setup: func() (tearDown func()) {
+				osExit = func(code int) {
+					require.Equal(t, 1, code)
+				}
+
+				return func() {
+					osExit = os.Exit
+				}
+			},
+			extraValidations: func(tb testing.TB) {
+				require.NoDirExists(tb, path.Join(testOutput, "env"))
+			},
+		},
+		{
+			name: "failing generator should exit with an error",
+			args: args{
+				configFile: path.Join(testdataFolder, "generate.config.invalid.yaml"),
+				output:     path.Join(testOutput, "invalid"),
+				stack:      "mystack",
+			},
+			setup: func() (tearDown func()) {
+				osExit = func(code int) {
+					require.Equal(t, 1, code)
+				}
+
+				return func() {
+					osExit = os.Exit
+				}
+			},
+			extraValidations: func(tb testing.TB) {
+				require.NoFileExists(tb, path.Join(testOutput, "invalid", "mystack", "mod", "target-sqs.tf"))
+			},
+		},
+		{
+			name: "config file does not exist",
+			args: args{
+				configFile: "fileDoesNotExist.yaml",
+				output:     path.Join(testOutput, "generate"),
+			},
+			setup: func() (tearDown func()) {
+				osExit = func(code int) {
+					require.Equal(t, 1, code)
+				}
+
+				return func() {
+					osExit = os.Exit
+				}
+			},
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.setup != nil {
+				tearDown := tc.setup()
+				defer tearDown()
+			}
+
+			_ = generateCmd.Flags().Set(flagConfig, tc.args.configFile)
+			_ = generateCmd.Flags().Set(flagOutput, tc.args.output)
+			_ = generateCmd.Flags().Set(flagStack, tc.args.stack)
+			_ = rootCmd.PersistentFlags().Set(flagDryRun, strconv.FormatBool(tc.args.dryRun))
+			_ = rootCmd.PersistentFlags().Set(flagEnv, tc.args.env)
+			// A slice flag appends values once it has been changed.
+			generateCmd.Flags().Lookup(flagOnly).Changed = false
+			_ = generateCmd.Flags().Lookup(flagOnly).Value.(pflag.SliceValue).Replace(nil)
+			_ = generateCmd.Flags().Set(flagOnly, tc.args.only)
+
+			generateCmd.Run(generateCmd, []string{})
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t)
+			}
+		})
+	}
+}
+
+func TestGenerate_RunWithChangedConfig(t *testing.T) {
+	output := path.Join(testOutput, "regenerate")
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	_ = generateCmd.Flags().Set(flagOutput, output)
+	_ = generateCmd.Flags().Set(flagStack, "mystack")
+	_ = rootCmd.PersistentFlags().Set(flagDryRun, "false")
+	_ = rootCmd.PersistentFlags().Set(flagEnv, "")
+	// Setting a slice flag appends to the values set by the other tests, so they are replaced instead.
+	_ = generateCmd.Flags().Lookup(flagOnly).Value.(pflag.SliceValue).Replace(nil)
+
+	for _, configFile := range []string{"regenerate.config.yaml", "regenerate.config.changed.yaml"} {
+		_ = generateCmd.Flags().Set(flagConfig, path.Join(testdataFolder, configFile))
+
+		generateCmd.Run(generateCmd, []string{})
+	}
+
+	modPath := path.Join(output, "mystack", "mod")
+
+	data, err := os.ReadFile(path.Join(modPath, "sqs.tf"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), `resource "aws_sqs_queue" "target_sqs"`)
+
+	data, err = os.ReadFile(path.Join(modPath, "outputs.tf"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "aws_sqs_queue.target_sqs")
+}
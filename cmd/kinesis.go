@@ -21,10 +21,14 @@ var kinesisCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = kinesis.NewKinesis(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = kinesis.NewKinesis(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -21,10 +21,14 @@ var lambdaCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = lambda.NewLambda(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = lambda.NewLambda(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/module"
+)
+
+// moduleCmd represents the module command.
+var moduleCmd = &cobra.Command{
+	Use:   "module",
+	Short: "Generate the variables and outputs of the generated modules",
+	Run: func(cmd *cobra.Command, _ []string) {
+		configFileName, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		stackName, err := cmd.Flags().GetString(flagStack)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		if stackName == "" {
+			stackName = defaultStackName(configFileName)
+		}
+
+		fs := newFileSystem(cmd)
+
+		err = module.NewModule(configFileName, output, stackName, newGeneratorOptions(cmd, fs)...).Build()
+		if err != nil {
+			printBuildErrorAndExit(err)
+		}
+
+		printDryRun(fs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(moduleCmd)
+
+	moduleCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./diagram.yaml")
+	moduleCmd.Flags().StringP(flagOutput, "o", "",
+		"Path to the output folder used to generate the code. For example: ./output")
+	moduleCmd.Flags().StringP(flagStack, "s", "",
+		"Name of the stack. Default: the name of the folder that contains the configuration file")
+
+	_ = moduleCmd.MarkFlagRequired(flagConfig)
+	_ = moduleCmd.MarkFlagRequired(flagOutput)
+}
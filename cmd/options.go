@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// newGeneratorOptions returns the options shared by the generators, taken from the global flags.
+func newGeneratorOptions(cmd *cobra.Command, fs filesystem.FileSystem) []generators.Option {
+	return []generators.Option{
+		generators.WithFileSystem(fs),
+		generators.WithYAMLOptions(newYAMLOptions(cmd)...),
+	}
+}
+
+// newYAMLOptions returns the options used to load the configuration file, taken from the global flags.
+func newYAMLOptions(cmd *cobra.Command) []config.YAMLOption {
+	var opts []config.YAMLOption
+
+	if flag := cmd.Flag(flagEnv); flag != nil && flag.Value.String() != "" {
+		opts = append(opts, config.WithEnvironment(flag.Value.String()))
+	}
+
+	if flag := cmd.Flag(flagVar); flag != nil && flag.Changed {
+		values, _ := cmd.Flags().GetStringArray(flagVar)
+
+		vars := make(map[string]string, len(values))
+
+		for _, value := range values {
+			k, v, ok := strings.Cut(value, "=")
+			if !ok || k == "" {
+				printErrorAndExit(fmt.Errorf("%w: %s", ErrInvalidVar, value))
+				continue
+			}
+
+			vars[k] = v
+		}
+
+		opts = append(opts, config.WithVars(vars))
+	}
+
+	return opts
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/packager"
+)
+
+// packageCmd represents the package command.
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Build and zip the generated Lambdas at the paths their Terraform refers to",
+	Run: func(cmd *cobra.Command, _ []string) {
+		configFileName, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		stackName, err := cmd.Flags().GetString(flagStack)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		if stackName == "" {
+			stackName = defaultStackName(configFileName)
+		}
+
+		opts := []packager.Option{packager.WithYAMLOptions(newYAMLOptions(cmd)...)}
+		if isFlagSet(cmd, flagDryRun) {
+			opts = append(opts, packager.WithDryRun())
+		}
+
+		_, err = packager.NewPackager(configFileName, output, stackName, opts...).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(packageCmd)
+
+	packageCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./diagram.yaml")
+	packageCmd.Flags().StringP(flagOutput, "o", "",
+		"Path to the output folder used to generate the code. For example: ./output")
+	packageCmd.Flags().StringP(flagStack, "s", "",
+		"Name of the stack. Default: the name of the folder that contains the configuration file")
+
+	_ = packageCmd.MarkFlagRequired(flagConfig)
+	_ = packageCmd.MarkFlagRequired(flagOutput)
+}
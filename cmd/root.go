@@ -11,6 +11,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 	"github.com/joselitofilho/aws-terraform-generator/internal/guides"
 	surveyasker "github.com/joselitofilho/aws-terraform-generator/internal/survey"
 )
@@ -18,10 +20,16 @@ import (
 const (
 	flagConfig  = "config"
 	flagDiagram = "diagram"
+	flagDryRun  = "dry-run"
+	flagEnv     = "env"
 	flagFile    = "file"
+	flagForce   = "force"
 	flagLeft    = "left"
+	flagOnly    = "only"
 	flagOutput  = "output"
 	flagRight   = "right"
+	flagStack   = "stack"
+	flagVar     = "var"
 	flagWorkdir = "workdir"
 )
 
@@ -32,7 +40,10 @@ const (
 	optionExit                  = "Exit"
 )
 
-var ErrNoDiagramOrConfigFiles = errors.New("this directory does not contain any diagram or config files")
+var (
+	ErrNoDiagramOrConfigFiles = errors.New("this directory does not contain any diagram or config files")
+	ErrInvalidVar             = errors.New("invalid variable, expected key=value")
+)
 
 var osExit = os.Exit
 
@@ -129,42 +140,23 @@ var rootCmd = &cobra.Command{
 					printErrorAndExit(err)
 				}
 
-				stackOutput := fmt.Sprintf("%s/%s", answers.Output, answers.StackName)
+				yamlConfig, err := config.NewYAML(answers.Config, newYAMLOptions(cmd)...).Parse()
+				if err != nil {
+					printErrorAndExit(fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err))
+					break
+				}
 
-				fmtcolor.White.Println("→ Generating API Gateway code...")
-				_ = apigatewayCmd.Flags().Set(flagConfig, answers.Config)
-				_ = apigatewayCmd.Flags().Set(flagOutput, answers.Output)
-				apigatewayCmd.Run(apigatewayCmd, []string{})
-				fmt.Println()
+				fs := newFileSystem(cmd)
 
-				fmtcolor.White.Println("→ Generating Kinesis code...")
-				_ = kinesisCmd.Flags().Set(flagConfig, answers.Config)
-				_ = kinesisCmd.Flags().Set(flagOutput, stackOutput)
-				kinesisCmd.Run(kinesisCmd, []string{})
-				fmt.Println()
+				results, err := newGeneratePipeline(yamlConfig, answers.Config, answers.Output, answers.StackName,
+					newGeneratorOptions(cmd, fs)...).Run(nil)
 
-				fmtcolor.White.Println("→ Generating Lambda code...")
-				_ = lambdaCmd.Flags().Set(flagConfig, answers.Config)
-				_ = lambdaCmd.Flags().Set(flagOutput, stackOutput)
-				lambdaCmd.Run(lambdaCmd, []string{})
-				fmt.Println()
+				printGenerateSummary(results)
+				printDryRun(fs)
 
-				fmtcolor.White.Println("→ Generating S3 code...")
-				_ = s3Cmd.Flags().Set(flagConfig, answers.Config)
-				_ = s3Cmd.Flags().Set(flagOutput, stackOutput)
-				s3Cmd.Run(s3Cmd, []string{})
-				fmt.Println()
-
-				fmtcolor.White.Println("→ Generating SNS code...")
-				_ = snsCmd.Flags().Set(flagConfig, answers.Config)
-				_ = snsCmd.Flags().Set(flagOutput, stackOutput)
-				snsCmd.Run(snsCmd, []string{})
-				fmt.Println()
-
-				fmtcolor.White.Println("→ Generating SQS code...")
-				_ = sqsCmd.Flags().Set(flagConfig, answers.Config)
-				_ = sqsCmd.Flags().Set(flagOutput, stackOutput)
-				sqsCmd.Run(sqsCmd, []string{})
+				if err != nil {
+					printErrorAndExit(err)
+				}
 			default:
 				shouldContinue = false
 			}
@@ -188,6 +180,15 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().Bool(flagDryRun, false,
+		"Render the files in memory and show what would be new, changed or unchanged without writing them")
+	rootCmd.PersistentFlags().String(flagEnv, "",
+		"Environment whose overlay, declared in the environments section, is merged into the configuration. "+
+			"For example: dev")
+	rootCmd.PersistentFlags().StringArray(flagVar, nil,
+		"Variable that overrides the vars section of the configuration. Can be repeated. For example: region=us-east-1")
+	rootCmd.PersistentFlags().Bool(flagForce, false,
+		"Overwrite the existing files without user code regions that have been edited since they were generated")
 	rootCmd.Flags().StringP(flagWorkdir, "", ".",
 		"Path to the directory where diagrams and configuration files are stored for the project. For example: ./example")
 }
@@ -21,10 +21,14 @@ var s3Cmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = s3.NewS3(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = s3.NewS3(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
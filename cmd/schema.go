@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// schemaCmd represents the schema command.
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration file",
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		data, err := json.MarshalIndent(config.NewJSONSchema(), "", "  ")
+		if err != nil {
+			printErrorAndExit(err)
+			return
+		}
+
+		data = append(data, '\n')
+
+		if output == "" {
+			fmt.Print(string(data))
+			return
+		}
+
+		fs := newFileSystem(cmd)
+
+		if err := fs.WriteFile(output, data); err != nil {
+			printBuildErrorAndExit(err)
+			return
+		}
+
+		fmtcolor.White.Printf("JSON Schema '%s' has been generated successfully\n", output)
+
+		printDryRun(fs)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().StringP(flagOutput, "o", "",
+		"Path to the output file. Default: standard output. For example: ./config.schema.json")
+}
@@ -21,10 +21,14 @@ var snsCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = sns.NewSNS(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = sns.NewSNS(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -21,10 +21,14 @@ var sqsCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = sqs.NewSQS(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = sqs.NewSQS(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -21,10 +21,14 @@ var structureCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = structure.NewStructure(config, output).Build()
+		fs := newFileSystem(cmd)
+
+		err = structure.NewStructure(config, output, newGeneratorOptions(cmd, fs)...).Build()
 		if err != nil {
-			printErrorAndExit(err)
+			printBuildErrorAndExit(err)
 		}
+
+		printDryRun(fs)
 	},
 }
 
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// validateCmd represents the validate command.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a configuration file",
+	Run: func(cmd *cobra.Command, _ []string) {
+		configFileName, err := cmd.Flags().GetString(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		issues, err := config.NewValidator(configFileName, newYAMLOptions(cmd)...).Validate()
+		if err != nil {
+			printErrorAndExit(err)
+			return
+		}
+
+		if len(issues) == 0 {
+			fmtcolor.Green.Printf("Configuration '%s' is valid\n", configFileName)
+			return
+		}
+
+		for _, issue := range issues {
+			fmtcolor.Red.Println(issue)
+		}
+
+		printErrorAndExit(fmt.Errorf("%d problem(s) found in '%s'", len(issues), configFileName))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./diagram.yaml")
+
+	_ = validateCmd.MarkFlagRequired(flagConfig)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		configFile string
+		env        string
+		exitCode   int
+	}{
+		{
+			name:       "valid config",
+			configFile: path.Join(testdataFolder, "generate.config.yaml"),
+		},
+		{
+			name:       "invalid config",
+			configFile: path.Join(testdataFolder, "validate.config.yaml"),
+			exitCode:   1,
+		},
+		{
+			name:       "unknown environment",
+			configFile: path.Join(testdataFolder, "generate.config.yaml"),
+			env:        "prod",
+			exitCode:   1,
+		},
+		{
+			name:       "config file does not exist",
+			configFile: "fileDoesNotExist.yaml",
+			exitCode:   1,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			exitCode := 0
+			osExit = func(code int) {
+				exitCode = code
+			}
+
+			defer func() {
+				osExit = os.Exit
+			}()
+
+			_ = validateCmd.Flags().Set(flagConfig, tc.configFile)
+			_ = rootCmd.PersistentFlags().Set(flagEnv, tc.env)
+
+			validateCmd.Run(validateCmd, []string{})
+
+			require.Equal(t, tc.exitCode, exitCode)
+		})
+	}
+}
@@ -9,9 +9,12 @@ require (
 	github.com/diagram-code-generator/template v1.0.0
 	github.com/ettle/strcase v0.2.0
 	github.com/fatih/color v1.16.0
+	github.com/hashicorp/hcl/v2 v2.20.1
 	github.com/joselitofilho/drawio-parser-go v0.3.2
 	github.com/joselitofilho/hcl-parser-go v0.1.0
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/spf13/cobra v1.8.0
+	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.9.0
 	go.uber.org/mock v0.4.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -22,15 +25,13 @@ require (
 	github.com/apparentlymart/go-textseg/v15 v15.0.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/emicklei/dot v1.6.1 // indirect
-	github.com/hashicorp/hcl/v2 v2.20.1 // indirect
+	github.com/google/go-cmp v0.6.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/kballard/go-shellquote v0.0.0-20180428030007-95032a82bc51 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b // indirect
 	github.com/mitchellh/go-wordwrap v0.0.0-20150314170334-ad45545899c7 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/zclconf/go-cty v1.14.4 // indirect
 	golang.org/x/mod v0.11.0 // indirect
 	golang.org/x/sys v0.14.0 // indirect
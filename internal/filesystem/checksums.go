@@ -0,0 +1,95 @@
+package filesystem
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChecksumsFileName is the file, written next to the generated files, that holds the SHA256 of their last generated
+// content, in the format of the sha256sum tool.
+const ChecksumsFileName = ".aws-terraform-generator.sum"
+
+// checksums keeps the SHA256 of the last generated content of the files by folder and name.
+type checksums struct {
+	fs   FileSystem
+	dirs map[string]map[string]string
+}
+
+func newChecksums(fs FileSystem) *checksums {
+	return &checksums{fs: fs, dirs: map[string]map[string]string{}}
+}
+
+// generated reports whether the content is the one last generated for the file, that is, it has not been edited.
+func (c *checksums) generated(name string, data []byte) (bool, error) {
+	sums, err := c.load(filepath.Dir(name))
+	if err != nil {
+		return false, err
+	}
+
+	sum, ok := sums[filepath.Base(name)]
+
+	return ok && sum == checksum(data), nil
+}
+
+// record stores the SHA256 of the content generated for the file and rewrites the checksums file of its folder.
+func (c *checksums) record(name string, data []byte) error {
+	dir := filepath.Dir(name)
+
+	sums, err := c.load(dir)
+	if err != nil {
+		return err
+	}
+
+	sums[filepath.Base(name)] = checksum(data)
+
+	names := make([]string, 0, len(sums))
+	for fileName := range sums {
+		names = append(names, fileName)
+	}
+
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, fileName := range names {
+		sb.WriteString(fmt.Sprintf("%s  %s\n", sums[fileName], fileName))
+	}
+
+	return c.fs.WriteFile(filepath.Join(dir, ChecksumsFileName), []byte(sb.String()))
+}
+
+// load reads the checksums file of the folder from disk the first time the folder is used.
+func (c *checksums) load(dir string) (map[string]string, error) {
+	if sums, ok := c.dirs[dir]; ok {
+		return sums, nil
+	}
+
+	sums := map[string]string{}
+
+	data, err := os.ReadFile(filepath.Join(dir, ChecksumsFileName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if sum, fileName, ok := strings.Cut(line, "  "); ok {
+			sums[fileName] = sum
+		}
+	}
+
+	c.dirs[dir] = sums
+
+	return sums, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
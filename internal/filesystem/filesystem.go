@@ -0,0 +1,43 @@
+package filesystem
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const filePerm = 0o666
+
+// FileSystem is the single entry point used by the generators to write their output.
+type FileSystem interface {
+	MkdirAll(dir string) error
+	WriteFile(name string, data []byte) error
+}
+
+// OS writes straight to disk.
+type OS struct{}
+
+func NewOS() *OS {
+	return &OS{}
+}
+
+func (*OS) MkdirAll(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// WriteFile writes the file, creating its parent directories when needed.
+func (f *OS) WriteFile(name string, data []byte) error {
+	if err := f.MkdirAll(filepath.Dir(name)); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(name, data, filePerm); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
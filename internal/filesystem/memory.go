@@ -0,0 +1,106 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// ChangeStatus represents how a file written in memory compares with the one on disk.
+type ChangeStatus string
+
+const (
+	StatusNew       ChangeStatus = "new"
+	StatusChanged   ChangeStatus = "changed"
+	StatusUnchanged ChangeStatus = "unchanged"
+)
+
+// Change represents a file that would be written. Diff holds the unified diff when the file has changed.
+type Change struct {
+	Path   string
+	Status ChangeStatus
+	Diff   string
+}
+
+// Memory keeps the written files in memory, so they can be compared with the ones on disk without touching them.
+type Memory struct {
+	paths []string
+	files map[string][]byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{files: map[string][]byte{}}
+}
+
+func (*Memory) MkdirAll(_ string) error {
+	return nil
+}
+
+// WriteFile keeps the content in memory. Writing the same file twice keeps the last content.
+func (m *Memory) WriteFile(name string, data []byte) error {
+	if _, ok := m.files[name]; !ok {
+		m.paths = append(m.paths, name)
+	}
+
+	m.files[name] = append([]byte(nil), data...)
+
+	return nil
+}
+
+// Changes compares every file kept in memory with the one on disk, in the order they were written.
+func (m *Memory) Changes() ([]Change, error) {
+	changes := make([]Change, 0, len(m.paths))
+
+	for _, name := range m.paths {
+		change, err := compare(name, m.files[name])
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+func compare(name string, data []byte) (Change, error) {
+	current, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Change{Path: name, Status: StatusNew}, nil
+	}
+
+	if err != nil {
+		return Change{}, fmt.Errorf("%w", err)
+	}
+
+	if string(current) == string(data) {
+		return Change{Path: name, Status: StatusUnchanged}, nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(current)),
+		B:        splitLines(string(data)),
+		FromFile: name,
+		ToFile:   name + " (generated)",
+		Context:  3,
+	})
+	if err != nil {
+		return Change{}, fmt.Errorf("%w", err)
+	}
+
+	return Change{Path: name, Status: StatusChanged, Diff: diff}, nil
+}
+
+// splitLines splits the content keeping the line breaks, as expected by the unified diff.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
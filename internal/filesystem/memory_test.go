@@ -0,0 +1,58 @@
+package filesystem
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory_Changes(t *testing.T) {
+	testOutput := t.TempDir()
+
+	unchangedFile := path.Join(testOutput, "unchanged.tf")
+	require.NoError(t, os.WriteFile(unchangedFile, []byte("unchanged\n"), filePerm))
+
+	changedFile := path.Join(testOutput, "changed.tf")
+	require.NoError(t, os.WriteFile(changedFile, []byte("line1\nline2\n"), filePerm))
+
+	newFile := path.Join(testOutput, "mod", "new.tf")
+
+	memory := NewMemory()
+	require.NoError(t, memory.MkdirAll(path.Join(testOutput, "mod")))
+	require.NoError(t, memory.WriteFile(newFile, []byte("first\n")))
+	require.NoError(t, memory.WriteFile(unchangedFile, []byte("unchanged\n")))
+	require.NoError(t, memory.WriteFile(changedFile, []byte("line1\nline3\n")))
+	require.NoError(t, memory.WriteFile(newFile, []byte("new\n")))
+
+	changes, err := memory.Changes()
+	require.NoError(t, err)
+
+	require.Equal(t, []Change{
+		{Path: newFile, Status: StatusNew},
+		{Path: unchangedFile, Status: StatusUnchanged},
+		{
+			Path:   changedFile,
+			Status: StatusChanged,
+			Diff: "--- " + changedFile + "\n+++ " + changedFile + " (generated)\n" +
+				"@@ -1,2 +1,2 @@\n line1\n-line2\n+line3\n",
+		},
+	}, changes)
+
+	require.NoDirExists(t, path.Join(testOutput, "mod"))
+
+	data, err := os.ReadFile(changedFile)
+	require.NoError(t, err)
+	require.Equal(t, "line1\nline2\n", string(data))
+}
+
+func TestOS_WriteFile(t *testing.T) {
+	outputFile := path.Join(t.TempDir(), "mod", "lambda", "main.go")
+
+	require.NoError(t, NewOS().WriteFile(outputFile, []byte("package main\n")))
+
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", string(data))
+}
@@ -0,0 +1,114 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ErrSkipped represents a file that has not been overwritten, so that its hand edits are kept.
+var ErrSkipped = errors.New("skipped, edited since it was generated")
+
+// Preserve protects the hand edits of the files that already exist on disk. The user code regions of an existing file
+// are carried over into the new content. An existing file without user code regions is regenerated when it still has
+// the content last generated for it, and otherwise only overwritten when forced. Only files with one of the given
+// extensions are protected.
+type Preserve struct {
+	fs         FileSystem
+	force      bool
+	extensions map[string]struct{}
+	checksums  *checksums
+}
+
+func NewPreserve(fs FileSystem, force bool, extensions ...string) *Preserve {
+	exts := make(map[string]struct{}, len(extensions))
+	for _, ext := range extensions {
+		exts[ext] = struct{}{}
+	}
+
+	return &Preserve{fs: fs, force: force, extensions: exts, checksums: newChecksums(fs)}
+}
+
+// Unwrap returns the file system that writes the files.
+func (p *Preserve) Unwrap() FileSystem {
+	return p.fs
+}
+
+func (p *Preserve) MkdirAll(dir string) error {
+	return p.fs.MkdirAll(dir)
+}
+
+// WriteFile writes the file unless it has been edited since it was generated, in which case an ErrSkipped error is
+// returned.
+func (p *Preserve) WriteFile(name string, data []byte) error {
+	if _, ok := p.extensions[filepath.Ext(name)]; !ok {
+		return p.fs.WriteFile(name, data)
+	}
+
+	current, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return p.write(name, data)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if len(parseUserCode(string(current))) == 0 && len(parseUserCode(string(data))) == 0 {
+		generated, err := p.checksums.generated(name, current)
+		if err != nil {
+			return err
+		}
+
+		if !p.force && !generated && string(current) != string(data) {
+			return fmt.Errorf("%w: %s", ErrSkipped, name)
+		}
+
+		return p.write(name, data)
+	}
+
+	return p.write(name, []byte(mergeUserCode(string(current), string(data))))
+}
+
+// write writes the file and records its checksum, so the next run knows whether it has been edited.
+func (p *Preserve) write(name string, data []byte) error {
+	if err := p.fs.WriteFile(name, data); err != nil {
+		return err
+	}
+
+	return p.checksums.record(name, data)
+}
+
+// SplitSkipped separates the files skipped to keep their hand edits from the other errors joined into err.
+func SplitSkipped(err error) (skipped []error, rest error) {
+	if !errors.Is(err, ErrSkipped) {
+		return nil, err
+	}
+
+	var errs []error
+
+	var split func(err error)
+
+	split = func(err error) {
+		if !errors.Is(err, ErrSkipped) {
+			errs = append(errs, err)
+			return
+		}
+
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				split(e)
+			}
+
+			return
+		}
+
+		skipped = append(skipped, err)
+	}
+
+	split(err)
+
+	return skipped, errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreserve_WriteFile(t *testing.T) {
+	const (
+		userCode  = "package main\n\n// USER CODE BEGIN functions\nfunc helper() {}\n// USER CODE END functions\n"
+		generated = "package main\n\n// USER CODE BEGIN functions\n// USER CODE END functions\n"
+	)
+
+	tests := []struct {
+		name      string
+		fileName  string
+		previous  string
+		current   string
+		data      string
+		force     bool
+		want      string
+		targetErr error
+	}{
+		{
+			name:     "new file",
+			fileName: "main.go",
+			data:     "package main\n",
+			want:     "package main\n",
+		},
+		{
+			name:      "skip existing file without user code regions",
+			fileName:  "main.go",
+			current:   "package main // edited\n",
+			data:      "package main\n",
+			want:      "package main // edited\n",
+			targetErr: ErrSkipped,
+		},
+		{
+			name:     "regenerate existing file without user code regions that has not been edited",
+			fileName: "sqs.tf",
+			previous: "resource \"aws_sqs_queue\" \"source_sqs\" {}\n",
+			data:     "resource \"aws_sqs_queue\" \"source_sqs\" {}\nresource \"aws_sqs_queue\" \"target_sqs\" {}\n",
+			want:     "resource \"aws_sqs_queue\" \"source_sqs\" {}\nresource \"aws_sqs_queue\" \"target_sqs\" {}\n",
+		},
+		{
+			name:      "skip existing file without user code regions that has been edited since generated",
+			fileName:  "main.go",
+			previous:  "package main\n",
+			current:   "package main // edited\n",
+			data:      "package main // regenerated\n",
+			want:      "package main // edited\n",
+			targetErr: ErrSkipped,
+		},
+		{
+			name:     "overwrite existing file without user code regions when forced",
+			fileName: "main.go",
+			current:  "package main // edited\n",
+			data:     "package main\n",
+			force:    true,
+			want:     "package main\n",
+		},
+		{
+			name:     "preserve user code regions",
+			fileName: "lambda.go",
+			current:  userCode,
+			data:     generated,
+			want:     userCode,
+		},
+		{
+			name:     "preserve user code regions when forced",
+			fileName: "lambda.go",
+			current:  userCode,
+			data:     generated,
+			force:    true,
+			want:     userCode,
+		},
+		{
+			name:     "overwrite unprotected extension",
+			fileName: "diagram.dot",
+			current:  "digraph {}\n",
+			data:     "digraph G {}\n",
+			want:     "digraph G {}\n",
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			fileName := path.Join(t.TempDir(), tc.fileName)
+
+			if tc.previous != "" {
+				require.NoError(t, NewPreserve(NewOS(), false, ".go", ".tf").WriteFile(fileName, []byte(tc.previous)))
+			}
+
+			if tc.current != "" {
+				require.NoError(t, os.WriteFile(fileName, []byte(tc.current), filePerm))
+			}
+
+			err := NewPreserve(NewOS(), tc.force, ".go", ".tf").WriteFile(fileName, []byte(tc.data))
+			require.ErrorIs(t, err, tc.targetErr)
+
+			data, err := os.ReadFile(fileName)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(data))
+		})
+	}
+}
+
+func TestSplitSkipped(t *testing.T) {
+	errFailed := errors.New("failed")
+	errSkipped := fmt.Errorf("%w: main.go", ErrSkipped)
+
+	skipped, err := SplitSkipped(errors.Join(errSkipped, errors.Join(errFailed, nil)))
+	require.Equal(t, []error{errSkipped}, skipped)
+	require.ErrorIs(t, err, errFailed)
+	require.NotErrorIs(t, err, ErrSkipped)
+
+	skipped, err = SplitSkipped(errSkipped)
+	require.Equal(t, []error{errSkipped}, skipped)
+	require.NoError(t, err)
+
+	skipped, err = SplitSkipped(nil)
+	require.Empty(t, skipped)
+	require.NoError(t, err)
+}
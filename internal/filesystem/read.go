@@ -0,0 +1,59 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// unwrapper is implemented by the file systems that write through another one.
+type unwrapper interface {
+	Unwrap() FileSystem
+}
+
+// ReadFiles returns the content of the files of the folder by name, as they have been written through the file system.
+// The files kept in memory by a dry run take precedence over the ones on disk.
+func ReadFiles(fsys FileSystem, dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		files[entry.Name()] = data
+	}
+
+	for {
+		w, ok := fsys.(unwrapper)
+		if !ok {
+			break
+		}
+
+		fsys = w.Unwrap()
+	}
+
+	if memory, ok := fsys.(*Memory); ok {
+		dir = filepath.Clean(dir)
+
+		for name, data := range memory.files {
+			if filepath.Dir(filepath.Clean(name)) == dir {
+				files[filepath.Base(name)] = data
+			}
+		}
+	}
+
+	return files, nil
+}
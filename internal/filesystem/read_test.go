@@ -0,0 +1,62 @@
+package filesystem
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFiles(t *testing.T) {
+	testOutput := t.TempDir()
+
+	require.NoError(t, os.WriteFile(path.Join(testOutput, "disk.tf"), []byte("disk"), filePerm))
+	require.NoError(t, os.WriteFile(path.Join(testOutput, "changed.tf"), []byte("old"), filePerm))
+	require.NoError(t, os.Mkdir(path.Join(testOutput, "folder"), os.ModePerm))
+
+	memory := NewMemory()
+	require.NoError(t, memory.WriteFile(path.Join(testOutput, "changed.tf"), []byte("new")))
+	require.NoError(t, memory.WriteFile(path.Join(testOutput, "memory.tf"), []byte("memory")))
+	require.NoError(t, memory.WriteFile(path.Join(testOutput, "folder", "other.tf"), []byte("other")))
+
+	tests := []struct {
+		name string
+		fs   FileSystem
+		dir  string
+		want map[string][]byte
+	}{
+		{
+			name: "files on disk",
+			fs:   NewOS(),
+			dir:  testOutput,
+			want: map[string][]byte{"disk.tf": []byte("disk"), "changed.tf": []byte("old")},
+		},
+		{
+			name: "files kept in memory take precedence over the ones on disk",
+			fs:   NewPreserve(memory, false, ".tf"),
+			dir:  testOutput,
+			want: map[string][]byte{
+				"disk.tf":    []byte("disk"),
+				"changed.tf": []byte("new"),
+				"memory.tf":  []byte("memory"),
+			},
+		},
+		{
+			name: "folder that does not exist",
+			fs:   NewOS(),
+			dir:  path.Join(testOutput, "missing"),
+			want: map[string][]byte{},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadFiles(tc.fs, tc.dir)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package filesystem
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	userCodeBeginRegex = regexp.MustCompile(`^\s*(?://|#)\s*USER CODE BEGIN (\S+)\s*$`)
+	userCodeEndRegex   = regexp.MustCompile(`^\s*(?://|#)\s*USER CODE END (\S+)\s*$`)
+)
+
+// userCodeRegion represents the lines written between the USER CODE BEGIN and USER CODE END markers of a region.
+type userCodeRegion struct {
+	name  string
+	begin string
+	lines []string
+	end   string
+}
+
+// parseUserCode returns the user code regions of the content, in the order they appear. Regions without an end
+// marker are ignored.
+func parseUserCode(content string) []userCodeRegion {
+	var (
+		regions []userCodeRegion
+		current *userCodeRegion
+	)
+
+	for _, line := range strings.Split(content, "\n") {
+		if current == nil {
+			if matches := userCodeBeginRegex.FindStringSubmatch(line); matches != nil {
+				current = &userCodeRegion{name: matches[1], begin: line}
+			}
+
+			continue
+		}
+
+		if matches := userCodeEndRegex.FindStringSubmatch(line); matches != nil && matches[1] == current.name {
+			current.end = line
+			regions = append(regions, *current)
+			current = nil
+
+			continue
+		}
+
+		current.lines = append(current.lines, line)
+	}
+
+	return regions
+}
+
+// mergeUserCode carries over the user code regions of the current content into the generated one. Regions that the
+// generated content does not declare are appended at the end, so code added by hand is never lost.
+func mergeUserCode(current, generated string) string {
+	currentRegions := parseUserCode(current)
+
+	currentByName := make(map[string]userCodeRegion, len(currentRegions))
+	for _, region := range currentRegions {
+		currentByName[region.name] = region
+	}
+
+	merged := make([]string, 0, strings.Count(generated, "\n")+1)
+	declared := map[string]struct{}{}
+
+	var inRegion string
+
+	for _, line := range strings.Split(generated, "\n") {
+		if inRegion != "" {
+			if matches := userCodeEndRegex.FindStringSubmatch(line); matches != nil && matches[1] == inRegion {
+				merged = append(merged, line)
+				inRegion = ""
+			}
+
+			continue
+		}
+
+		merged = append(merged, line)
+
+		matches := userCodeBeginRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		region, ok := currentByName[matches[1]]
+		if !ok || !hasEndMarker(generated, matches[1]) {
+			continue
+		}
+
+		declared[region.name] = struct{}{}
+		inRegion = region.name
+
+		merged = append(merged, region.lines...)
+	}
+
+	var missing []string
+
+	for _, region := range currentRegions {
+		if _, ok := declared[region.name]; ok {
+			continue
+		}
+
+		missing = append(missing, region.begin)
+		missing = append(missing, region.lines...)
+		missing = append(missing, region.end)
+	}
+
+	result := strings.Join(merged, "\n")
+
+	if len(missing) > 0 {
+		result = strings.TrimRight(result, "\n") + "\n\n" + strings.Join(missing, "\n") + "\n"
+	}
+
+	return result
+}
+
+func hasEndMarker(content, name string) bool {
+	for _, region := range parseUserCode(content) {
+		if region.name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,53 @@
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeUserCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		generated string
+		want      string
+	}{
+		{
+			name: "carry over the regions declared by the generated content",
+			current: "func run() error {\n\t// USER CODE BEGIN run\n\treturn doSomething()\n\t// USER CODE END run\n}\n" +
+				"// USER CODE BEGIN functions\nfunc doSomething() error { return nil }\n// USER CODE END functions\n",
+			generated: "func run(ctx context.Context) error {\n\t// USER CODE BEGIN run\n\treturn nil\n" +
+				"\t// USER CODE END run\n}\n// USER CODE BEGIN functions\n// USER CODE END functions\n",
+			want: "func run(ctx context.Context) error {\n\t// USER CODE BEGIN run\n\treturn doSomething()\n" +
+				"\t// USER CODE END run\n}\n// USER CODE BEGIN functions\nfunc doSomething() error { return nil }\n" +
+				"// USER CODE END functions\n",
+		},
+		{
+			name:      "append the regions that the generated content does not declare",
+			current:   "resource \"aws_sqs_queue\" \"old\" {}\n\n# USER CODE BEGIN extra\nlocals {}\n# USER CODE END extra\n",
+			generated: "resource \"aws_sqs_queue\" \"new\" {}\n",
+			want:      "resource \"aws_sqs_queue\" \"new\" {}\n\n# USER CODE BEGIN extra\nlocals {}\n# USER CODE END extra\n",
+		},
+		{
+			name:      "keep the generated region when the current content does not have it",
+			current:   "package main\n",
+			generated: "// USER CODE BEGIN run\nreturn nil\n// USER CODE END run\n",
+			want:      "// USER CODE BEGIN run\nreturn nil\n// USER CODE END run\n",
+		},
+		{
+			name:      "ignore unterminated regions",
+			current:   "// USER CODE BEGIN run\nreturn err\n",
+			generated: "// USER CODE BEGIN run\nreturn nil\n// USER CODE END run\n",
+			want:      "// USER CODE BEGIN run\nreturn nil\n// USER CODE END run\n",
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, mergeUserCode(tc.current, tc.generated))
+		})
+	}
+}
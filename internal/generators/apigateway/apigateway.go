@@ -2,29 +2,61 @@ package apigateway
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorerrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/iam"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
+const (
+	// Identity source of the authorizers that do not set one.
+	defaultIdentitySource = "$request.header.Authorization"
+
+	// Authorization types of the routes protected by a JWT or a Lambda authorizer.
+	authorizationTypeJWT    = "JWT"
+	authorizationTypeCustom = "CUSTOM"
+
+	// Route selection expression of the WebSocket APIs that do not set one.
+	defaultRouteSelectionExpression = "$request.body.action"
+
+	// Environment variables of the handlers of a WebSocket API, with the name of the connection table and the
+	// endpoint sending messages back to the clients.
+	envarWebSocketConnectionsTable = "WEBSOCKET_CONNECTIONS_TABLE"
+	envarWebSocketAPIEndpoint      = "WEBSOCKET_API_ENDPOINT"
+)
+
 type APIGateway struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewAPIGateway(configFileName, output string) *APIGateway {
-	return &APIGateway{configFileName: configFileName, output: output}
+func NewAPIGateway(configFileName, output string, opts ...generators.Option) *APIGateway {
+	options := generators.NewOptions(opts...)
+
+	return &APIGateway{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (a *APIGateway) Build() error {
-	yamlParser := config.NewYAML(a.configFileName)
+	yamlParser := config.NewYAML(a.configFileName, a.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -41,83 +73,388 @@ func (a *APIGateway) Build() error {
 			filenameTfLambda, generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)),
 	)[filenameTfLambda]
 
-	goTemplates := utils.MergeStringMap(defaultGoTemplateFiles, generators.FilterTemplatesMap(".go",
-		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)))
+	integrationTfTemplate := utils.MergeStringMap(map[string]string{filenameTfIntegration: string(tmplIntegrationTf)},
+		generators.FilterTemplatesMap(
+			filenameTfIntegration, generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)),
+	)[filenameTfIntegration]
+
+	webSocketTfTemplate := utils.MergeStringMap(map[string]string{filenameTfWebSocket: string(tmplWebSocketTf)},
+		generators.FilterTemplatesMap(
+			filenameTfWebSocket, generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)),
+	)[filenameTfWebSocket]
+
+	overrideTemplates := generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)
+
+	codeTemplates := map[string]map[string]string{}
+	webSocketCodeTemplates := map[string]map[string]string{}
+
+	for family, templates := range defaultCodeTemplateFiles {
+		codeTemplates[family] = utils.MergeStringMap(maps.Clone(templates),
+			generators.FilterRuntimeTemplatesMap(family, overrideTemplates))
+
+		webSocketTemplates := maps.Clone(templates)
+		if family == generators.RuntimeGo {
+			webSocketTemplates[filenameGoLambda] = string(tmplWebSocketLambdaGo)
+		}
+
+		webSocketCodeTemplates[family] = utils.MergeStringMap(webSocketTemplates,
+			generators.FilterRuntimeTemplatesMap(family, overrideTemplates))
+	}
+
+	policies, err := iam.NewPolicies(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
-	apigHasAlreadyGeneratedByStack := map[string]struct{}{}
+	iamTfTemplate := iam.Template(yamlConfig)
+
+	apigHasAlreadyGeneratedByLabel := map[string]struct{}{}
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.APIGateways {
 		apiConf := yamlConfig.APIGateways[i]
 		stackName := apiConf.StackName
 
 		outputMod := path.Join(a.output, stackName, "mod")
-		_ = os.MkdirAll(outputMod, os.ModePerm)
 
-		if _, ok := apigHasAlreadyGeneratedByStack[stackName]; !ok && apiConf.APIG {
-			apigHasAlreadyGeneratedByStack[stackName] = struct{}{}
+		if _, ok := apigHasAlreadyGeneratedByLabel[apiLabel(&apiConf)]; !ok && apiConf.APIG {
+			apigHasAlreadyGeneratedByLabel[apiLabel(&apiConf)] = struct{}{}
+
+			fileName, tmpl := filenameTfAPIG, apigTfTemplate
+			if apiConf.IsWebSocket() {
+				fileName, tmpl = filenameTfWebSocket, webSocketTfTemplate
+			}
+
+			data := buildData(&apiConf, yamlConfig.ResourceTags(apiConf.Tags))
+
+			if err := generators.GenerateFile(tg, a.fs, nil, fileName, tmpl, path.Join(outputMod, fileName), data); err != nil {
+				errs = append(errs, err)
+			} else {
+				fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", fileName)
+			}
+		}
+
+		lambdaCodeTemplates := codeTemplates
+		if apiConf.IsWebSocket() {
+			lambdaCodeTemplates = webSocketCodeTemplates
+		}
+
+		for j := range apiConf.Lambdas {
+			lambdaConf := &apiConf.Lambdas[j]
+
+			tags := yamlConfig.ResourceTags(apiConf.Tags, lambdaConf.Tags)
 
-			outputFile := path.Join(outputMod, filenameTfAPIG)
+			roleName := lambdaConf.RoleName
+			if roleName == "" {
+				roleData := policies.Data(lambdaConf.Name)
+				roleData.Tags = tags
+				roleData.Statements = append(roleData.Statements, iam.FunctionStatements(&lambdaConf.LambdaFunction)...)
+				roleName = roleData.RoleName
 
-			data := Data{
+				if apiConf.IsWebSocket() {
+					roleData.Statements = append(roleData.Statements, iam.WebSocketStatements(
+						fmt.Sprintf("%s.%s.execution_arn", awsresources.LabelAWSAPIGatewayAPI, apiLabel(&apiConf)),
+						fmt.Sprintf("%s.%s.arn", awsresources.LabelAWSDynamoDBTable, connectionTableLabel(stackName)),
+					)...)
+				}
+
+				errs = append(errs, iam.GenerateRole(tg, a.fs, iamTfTemplate, roleData, outputMod))
+			}
+
+			errs = append(errs, buildLambdaFiles(a.fs, &apiConf, lambdaConf, roleName, tags, lambdaTfTemplate,
+				outputMod, a.output, lambdaCodeTemplates))
+		}
+
+		for j := range apiConf.Integrations {
+			integrationConf := &apiConf.Integrations[j]
+
+			data := IntegrationData{
+				Name:      integrationConf.Name,
+				Label:     strcase.ToSnake(integrationConf.Name),
 				StackName: stackName,
-				APIDomain: apiConf.APIDomain,
+				Type:      integrationConf.Type,
+				Target:    integrationTarget(integrationConf),
+				Routes:    buildRoutes(&apiConf, integrationConf.Name, integrationConf.GetRoutes()),
+				Tags:      yamlConfig.ResourceTags(apiConf.Tags),
 			}
 
-			generators.MustGenerateFile(tg, nil, filenameTfAPIG, apigTfTemplate, outputFile, data)
+			fileName := fmt.Sprintf("%s.tf", integrationConf.Name)
+
+			err := generators.GenerateFile(tg, a.fs, nil, fileName, integrationTfTemplate,
+				path.Join(outputMod, fileName), data)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
-			fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", filenameTfAPIG)
+			fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", fileName)
 		}
+	}
 
-		for j := range apiConf.Lambdas {
-			buildLambdaFiles(&apiConf.Lambdas[j], apiConf.StackName, lambdaTfTemplate, outputMod, a.output,
-				goTemplates)
+	return errors.Join(errs...)
+}
+
+// buildData returns the template data of the API, along with its authorizers, CORS configuration, throttling limits
+// and named stages.
+func buildData(apiConf *config.APIGateway, tags map[string]string) Data {
+	data := Data{
+		StackName: apiConf.StackName,
+		APIDomain: apiConf.APIDomain,
+		Tags:      tags,
+	}
+
+	if apiConf.IsWebSocket() {
+		data.RouteSelectionExpression = apiConf.RouteSelectionExpression
+		if data.RouteSelectionExpression == "" {
+			data.RouteSelectionExpression = defaultRouteSelectionExpression
+		}
+	}
+
+	for i := range apiConf.Authorizers {
+		data.Authorizers = append(data.Authorizers, buildAuthorizerData(&apiConf.Authorizers[i]))
+	}
+
+	if !apiConf.CORS.IsEmpty() {
+		data.CORS = &CORSData{
+			AllowOrigins:     generators.QuoteList(apiConf.CORS.AllowOrigins, true),
+			AllowMethods:     generators.QuoteList(apiConf.CORS.AllowMethods, true),
+			AllowHeaders:     generators.QuoteList(apiConf.CORS.AllowHeaders, true),
+			ExposeHeaders:    generators.QuoteList(apiConf.CORS.ExposeHeaders, true),
+			AllowCredentials: apiConf.CORS.AllowCredentials,
+			MaxAge:           apiConf.CORS.MaxAge,
+		}
+	}
+
+	if apiConf.Throttling != (config.APIGatewayThrottling{}) {
+		data.Throttling = &ThrottlingData{
+			BurstLimit: apiConf.Throttling.BurstLimit,
+			RateLimit:  apiConf.Throttling.RateLimit,
+		}
+	}
+
+	for i := range apiConf.Lambdas {
+		data.Routes = append(data.Routes,
+			buildRouteThrottlings(apiConf.Lambdas[i].Name, apiConf.Lambdas[i].GetRoutes())...)
+	}
+
+	for i := range apiConf.Integrations {
+		data.Routes = append(data.Routes,
+			buildRouteThrottlings(apiConf.Integrations[i].Name, apiConf.Integrations[i].GetRoutes())...)
+	}
+
+	for i := range apiConf.Stages {
+		data.Stages = append(data.Stages, StageData{
+			Name:      apiConf.Stages[i].Name,
+			Label:     strcase.ToSnake(apiConf.Stages[i].Name),
+			Variables: apiConf.Stages[i].Variables,
+		})
+	}
+
+	return data
+}
+
+func buildAuthorizerData(conf *config.APIGatewayAuthorizer) AuthorizerData {
+	identitySources := conf.IdentitySources
+	if len(identitySources) == 0 {
+		identitySources = []string{defaultIdentitySource}
+	}
+
+	data := AuthorizerData{
+		Name:               conf.Name,
+		Label:              strcase.ToSnake(conf.Name),
+		Type:               conf.Type,
+		IdentitySources:    generators.QuoteList(identitySources, true),
+		Audience:           generators.QuoteList(conf.Audience, false),
+		ResultTTLInSeconds: conf.ResultTTLInSeconds,
+	}
+
+	if conf.Issuer != "" {
+		data.Issuer = generators.QuoteIfLiteral(conf.Issuer)
+	}
+
+	if conf.FunctionARN != "" {
+		data.FunctionARN = generators.QuoteIfLiteral(conf.FunctionARN)
+	}
+
+	return data
+}
+
+// buildRouteThrottlings returns the throttling limits of the routes of a Lambda or an integration that set them.
+func buildRouteThrottlings(name string, routes []config.APIGatewayRoute) []ThrottlingData {
+	var throttlings []ThrottlingData
+
+	for i := range routes {
+		if routes[i].Throttling == (config.APIGatewayThrottling{}) {
+			continue
+		}
+
+		throttlings = append(throttlings, ThrottlingData{
+			RouteKey:   routes[i].RouteKey(),
+			RouteLabel: routeLabel(name, i),
+			BurstLimit: routes[i].Throttling.BurstLimit,
+			RateLimit:  routes[i].Throttling.RateLimit,
+		})
+	}
+
+	return throttlings
+}
+
+// buildRoutes returns the template data of the routes of a Lambda or an integration.
+func buildRoutes(apiConf *config.APIGateway, name string, routes []config.APIGatewayRoute) []RouteData {
+	data := make([]RouteData, 0, len(routes))
+
+	for i := range routes {
+		route := RouteData{Label: routeLabel(name, i), RouteKey: routes[i].RouteKey()}
+
+		if authorizationType := authorizationType(apiConf, routes[i].Authorizer); authorizationType != "" {
+			route.AuthorizerLabel = strcase.ToSnake(routes[i].Authorizer)
+			route.AuthorizationType = authorizationType
+			route.AuthorizationScopes = generators.QuoteList(routes[i].AuthorizationScopes, true)
+		}
+
+		data = append(data, route)
+	}
+
+	return data
+}
+
+// routeLabel returns the label of the i-th route of a Lambda or an integration. The first route keeps the label of
+// the single route of a Lambda.
+func routeLabel(name string, i int) string {
+	return "apigw_route_" + strcase.ToSnake(name) + generators.Suffix(i)
+}
+
+// integrationTarget returns the URL of an HTTP integration, or the address of the queue or the stream of a service
+// integration.
+func integrationTarget(conf *config.APIGatewayIntegration) string {
+	switch conf.Type {
+	case config.APIGatewayIntegrationSQS:
+		return fmt.Sprintf("%s.%s_sqs", awsresources.LabelAWSSQSQueue, strcase.ToSnake(conf.Target))
+	case config.APIGatewayIntegrationKinesis:
+		return fmt.Sprintf("%s.%s_kinesis", awsresources.LabelAWSKinesisStream, strcase.ToSnake(conf.Target))
+	default:
+		return generators.QuoteIfLiteral(conf.Target)
+	}
+}
+
+// apiLabel returns the label of the API of the API Gateway. The WebSocket API of a stack has its own label, so that
+// it can be deployed along with the HTTP API of the stack.
+func apiLabel(apiConf *config.APIGateway) string {
+	if apiConf.IsWebSocket() {
+		return apiConf.StackName + "_websocket_api"
+	}
+
+	return apiConf.StackName + "_api"
+}
+
+// connectionTableLabel returns the label of the DynamoDB table keeping the connections of the WebSocket API of the
+// stack.
+func connectionTableLabel(stackName string) string {
+	return stackName + "_websocket_connections"
+}
+
+// webSocketEnvars returns the environment variables of a handler of a WebSocket API, along with the name of the
+// connection table and the endpoint sending messages back to the clients, unless the Lambda sets them.
+func webSocketEnvars(apiConf *config.APIGateway, envars map[string]string) map[string]string {
+	result := map[string]string{
+		envarWebSocketConnectionsTable: fmt.Sprintf("%s.%s.name", awsresources.LabelAWSDynamoDBTable,
+			connectionTableLabel(apiConf.StackName)),
+		envarWebSocketAPIEndpoint: fmt.Sprintf(`replace(aws_apigatewayv2_stage.%s.invoke_url, "wss://", "https://")`,
+			apiLabel(apiConf)),
+	}
+
+	maps.Copy(result, envars)
+
+	return result
+}
+
+// authorizationType returns the authorization type of the routes protected by the authorizer, or empty when the API
+// does not define it.
+func authorizationType(apiConf *config.APIGateway, authorizerName string) string {
+	for i := range apiConf.Authorizers {
+		if apiConf.Authorizers[i].Name != authorizerName {
+			continue
+		}
+
+		if apiConf.Authorizers[i].Type == config.APIGatewayAuthorizerRequest {
+			return authorizationTypeCustom
 		}
+
+		return authorizationTypeJWT
 	}
 
-	return nil
+	return ""
 }
 
-func buildLambdaFiles(lambdaConf *config.APIGatewayLambda, stackName, lambdaTfTemplate, outputMod, output string,
-	goTemplates map[string]string,
-) {
+func buildLambdaFiles(
+	fs filesystem.FileSystem, apiConf *config.APIGateway, lambdaConf *config.APIGatewayLambda,
+	roleName string, tags map[string]string, lambdaTfTemplate, outputMod, output string,
+	codeTemplates map[string]map[string]string,
+) error {
 	tg := generators.NewGenerator()
 
+	stackName := apiConf.StackName
+
 	filesConf := generators.CreateFilesMap(lambdaConf.Files)
 
 	asModule := strings.Contains(lambdaConf.Source, "git@")
 
-	roleName := lambdaConf.RoleName
-	if roleName == "" {
-		roleName = "iam_for_lambda"
+	routes := lambdaConf.GetRoutes()
+
+	envars := lambdaConf.Envars
+	if apiConf.IsWebSocket() {
+		envars = webSocketEnvars(apiConf, envars)
 	}
 
 	lambdaData := LambdaData{
-		Name:        lambdaConf.Name,
-		AsModule:    asModule,
-		Source:      lambdaConf.Source,
-		RoleName:    roleName,
-		Runtime:     lambdaConf.Runtime,
-		StackName:   stackName,
-		Description: lambdaConf.Description,
-		Envars:      lambdaConf.Envars,
-		Verb:        lambdaConf.Verb,
-		Path:        lambdaConf.Path,
-		Files:       filesConf,
+		Name:         lambdaConf.Name,
+		AsModule:     asModule,
+		Source:       lambdaConf.Source,
+		RoleName:     roleName,
+		Runtime:      lambdaConf.Runtime,
+		Handler:      generators.RuntimeHandler(lambdaConf.Runtime, strcase.ToSnake(lambdaConf.Name)+"_lambda"),
+		StackName:    stackName,
+		APILabel:     apiLabel(apiConf),
+		WebSocket:    apiConf.IsWebSocket(),
+		Description:  lambdaConf.Description,
+		Envars:       envars,
+		FunctionData: generators.NewFunctionData(&lambdaConf.LambdaFunction),
+		Dependencies: generators.CreateDependencies(envars),
+		Routes:       buildRoutes(apiConf, lambdaConf.Name, routes),
+		Tags:         tags,
+		Files:        filesConf,
+	}
+
+	// The code templates test the first route.
+	if len(routes) > 0 {
+		lambdaData.Verb = routes[0].Verb
+		lambdaData.Path = routes[0].Path
 	}
 
 	fileName := fmt.Sprintf("%s.tf", lambdaConf.Name)
 	outputLambdaTfFile := path.Join(outputMod, fileName)
 
-	generators.MustGenerateFile(tg, nil, fileName, lambdaTfTemplate, outputLambdaTfFile, lambdaData)
+	var errs []error
 
-	fmtcolor.White.Printf("Terraform '%s.tf' has been generated successfully\n", fileName)
+	err := generators.GenerateFile(tg, fs, nil, fileName, lambdaTfTemplate, outputLambdaTfFile, lambdaData)
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", fileName)
+	}
 
 	outputLambda := path.Join(output, stackName, "lambda", lambdaConf.Name)
-	_ = os.MkdirAll(outputLambda, os.ModePerm)
 
-	generators.MustGenerateFiles(tg, goTemplates, filesConf, lambdaData, outputLambda)
+	err = generators.GenerateFiles(tg, fs, codeTemplates[generators.RuntimeFamily(lambdaConf.Runtime)], filesConf,
+		lambdaData, outputLambda)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
 
 	fmtcolor.White.Printf("Lambda '%s' has been generated successfully\n", lambdaData.Name)
+
+	return errors.Join(errs...)
 }
@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
@@ -44,10 +45,202 @@ func TestAPIGateway_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(modPath, "exampleAPIReceiver.tf"))
 
 				lambdaPath := path.Join(output, "teststack", "lambda", "exampleAPIReceiver")
+				require.FileExists(tb, path.Join(lambdaPath, "config.go"))
+				require.FileExists(tb, path.Join(lambdaPath, "dependencies.go"))
 				require.FileExists(tb, path.Join(lambdaPath, "lambda.go"))
+				require.FileExists(tb, path.Join(lambdaPath, "lambda_test.go"))
 				require.FileExists(tb, path.Join(lambdaPath, "main.go"))
 			},
 		},
+		{
+			name: "lambda without role name should generate its role",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "iam.config.yaml"),
+				output:         path.Join(testOutput, "iam"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+
+				iamTfData, err := os.ReadFile(path.Join(modPath, "ordersAPI-iam.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(iamTfData), `resource "aws_iam_role" "orders_api_lambda_role"`)
+				require.Contains(tb, string(iamTfData), "aws_kinesis_stream.orders_kinesis.arn")
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "ordersAPI.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), "aws_iam_role.orders_api_lambda_role.arn")
+
+				lambdaGoData, err := os.ReadFile(path.Join(output, "teststack", "lambda", "ordersAPI", "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "ctx context.Context, request events.APIGatewayV2HTTPRequest,")
+				require.Contains(tb, string(lambdaGoData), ") (events.APIGatewayV2HTTPResponse, error) {")
+
+				lambdaTestGoData, err := os.ReadFile(path.Join(output, "teststack", "lambda", "ordersAPI", "lambda_test.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTestGoData), `Method: "POST", Path: "/v1/orders"`)
+				require.Contains(tb, string(lambdaTestGoData), "OrdersKinesisStream: &fakeKinesisStream{},")
+			},
+		},
+		{
+			name: "lambda settings should be rendered in the function and its role",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.function.yaml"),
+				output:         path.Join(testOutput, "function"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "ordersAPI.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), "memory_size   = 256")
+				require.Contains(tb, string(lambdaTfData), `architectures = ["arm64"]`)
+				require.Contains(tb, string(lambdaTfData), `mode = "Active"`)
+				require.Contains(tb, string(lambdaTfData), `name              = "/aws/lambda/orders_api_lambda"`)
+				require.Contains(tb, string(lambdaTfData), "retention_in_days = 7")
+
+				iamTfData, err := os.ReadFile(path.Join(modPath, "ordersAPI-iam.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(iamTfData), `"xray:PutTraceSegments"`)
+			},
+		},
+		{
+			name: "authorizers, CORS, throttling and stages should be rendered in the API and its routes",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.security.yaml"),
+				output:         path.Join(testOutput, "security"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+
+				apigTfData, err := os.ReadFile(path.Join(modPath, "apig.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(apigTfData), `api_name       = "${var.client}-${var.environment}-teststack-api"`)
+				require.NotContains(tb, string(apigTfData), "aws_apigatewayv2_domain_name")
+				require.NotContains(tb, string(apigTfData), "aws_acm_certificate")
+				require.Contains(tb, string(apigTfData), `allow_origins = ["https://example.com"]`)
+				require.Contains(tb, string(apigTfData), "throttling_burst_limit = 100")
+				require.Contains(tb, string(apigTfData), `route_key              = "POST /v1/orders"`)
+				require.Contains(tb, string(apigTfData), `resource "aws_apigatewayv2_stage" "teststack_api_v1"`)
+				require.Contains(tb, string(apigTfData), `"environment" = "production"`)
+				require.Contains(tb, string(apigTfData), `resource "aws_apigatewayv2_authorizer" "teststack_cognito_authorizer"`)
+				require.Contains(tb, string(apigTfData), `audience = ["example-client-id"]`)
+				require.Contains(tb, string(apigTfData),
+					"functions/${aws_lambda_function.token_validator_lambda.arn}/invocations")
+				require.Contains(tb, string(apigTfData), `resource "aws_lambda_permission" "teststack_token_validator_authorizer"`)
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "ordersAPI.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `authorization_type   = "JWT"`)
+				require.Contains(tb, string(lambdaTfData),
+					"authorizer_id        = aws_apigatewayv2_authorizer.teststack_cognito_authorizer.id")
+				require.Contains(tb, string(lambdaTfData), `authorization_scopes = ["orders/write"]`)
+
+				lambdaTfData, err = os.ReadFile(path.Join(modPath, "ordersQuery.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `authorization_type = "CUSTOM"`)
+			},
+		},
+		{
+			name: "routes of the lambdas and the integrations should be rendered in their files",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.integrations.yaml"),
+				output:         path.Join(testOutput, "integrations"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "itemsAPI.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `resource "aws_apigatewayv2_route" "apigw_route_items_api"`)
+				require.Contains(tb, string(lambdaTfData), `route_key = "GET /items"`)
+				require.Contains(tb, string(lambdaTfData), `resource "aws_apigatewayv2_route" "apigw_route_items_api_2"`)
+				require.Contains(tb, string(lambdaTfData), `route_key = "POST /items"`)
+				require.Equal(tb, 1, strings.Count(string(lambdaTfData), `resource "aws_apigatewayv2_integration"`))
+
+				lambdaTfData, err = os.ReadFile(path.Join(modPath, "fallback.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `route_key = "$default"`)
+
+				apigTfData, err := os.ReadFile(path.Join(modPath, "apig.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(apigTfData), "aws_apigatewayv2_route.apigw_route_items_api_2,")
+
+				integrationTfData, err := os.ReadFile(path.Join(modPath, "ordersQueue.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(integrationTfData), `integration_subtype = "SQS-SendMessage"`)
+				require.Contains(tb, string(integrationTfData), `"QueueUrl"    = aws_sqs_queue.orders_sqs.url`)
+				require.Contains(tb, string(integrationTfData), "Resource = [aws_sqs_queue.orders_sqs.arn]")
+				require.Contains(tb, string(integrationTfData), `route_key = "POST /orders"`)
+
+				integrationTfData, err = os.ReadFile(path.Join(modPath, "eventsStream.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(integrationTfData), `integration_subtype = "Kinesis-PutRecord"`)
+				require.Contains(tb, string(integrationTfData), `"StreamName"   = aws_kinesis_stream.events_kinesis.name`)
+
+				integrationTfData, err = os.ReadFile(path.Join(modPath, "legacyProxy.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(integrationTfData), `integration_type   = "HTTP_PROXY"`)
+				require.Contains(tb, string(integrationTfData), "integration_uri    = var.legacy_api_base_url")
+				require.NotContains(tb, string(integrationTfData), "aws_iam_role")
+			},
+		},
+		{
+			name: "websocket api should be rendered along with the http api of the stack",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.websocket.yaml"),
+				output:         path.Join(testOutput, "websocket"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+
+				require.FileExists(tb, path.Join(modPath, "apig.tf"))
+
+				webSocketTfData, err := os.ReadFile(path.Join(modPath, "websocket.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(webSocketTfData), `protocol_type              = "WEBSOCKET"`)
+				require.Contains(tb, string(webSocketTfData), `route_selection_expression = "$request.body.action"`)
+				require.Contains(tb, string(webSocketTfData),
+					`resource "aws_dynamodb_table" "teststack_websocket_connections"`)
+				require.Contains(tb, string(webSocketTfData), "aws_apigatewayv2_route.apigw_route_chat,")
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "connect.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `route_key = "$connect"`)
+				require.Contains(tb, string(lambdaTfData), "api_id    = aws_apigatewayv2_api.teststack_websocket_api.id")
+				require.Contains(tb, string(lambdaTfData),
+					"WEBSOCKET_CONNECTIONS_TABLE = aws_dynamodb_table.teststack_websocket_connections.name")
+
+				iamTfData, err := os.ReadFile(path.Join(modPath, "chat-iam.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(iamTfData), `Action   = ["execute-api:ManageConnections"]`)
+				require.Contains(tb, string(iamTfData),
+					"Resource = [aws_dynamodb_table.teststack_websocket_connections.arn]")
+
+				lambdaGoData, err := os.ReadFile(path.Join(output, "teststack", "lambda", "chat", "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "request events.APIGatewayWebsocketProxyRequest")
+			},
+		},
 		{
 			name: "override default template for multiple apigateway",
 			fields: fields{
@@ -2,21 +2,95 @@ package apigateway
 
 import "github.com/joselitofilho/aws-terraform-generator/internal/generators"
 
+// AuthorizerData represents an authorizer of the API. The identity sources, issuer, audience and function ARN are
+// rendered as Terraform expressions.
+type AuthorizerData struct {
+	Name               string
+	Label              string
+	Type               string
+	IdentitySources    string
+	Issuer             string
+	Audience           string
+	FunctionARN        string
+	ResultTTLInSeconds int
+}
+
+// CORSData represents the CORS configuration of the API, with the lists rendered as Terraform list items.
+type CORSData struct {
+	AllowOrigins     string
+	AllowMethods     string
+	AllowHeaders     string
+	ExposeHeaders    string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+// ThrottlingData represents the throttling limits of the stages, or of a route when the route key is set.
+type ThrottlingData struct {
+	RouteKey   string
+	RouteLabel string
+	BurstLimit int
+	RateLimit  float64
+}
+
+// RouteData represents a route of the API, along with its authorizer when it has one.
+type RouteData struct {
+	Label               string
+	RouteKey            string
+	AuthorizerLabel     string
+	AuthorizationType   string
+	AuthorizationScopes string
+}
+
+// StageData represents a named stage of the API.
+type StageData struct {
+	Name      string
+	Label     string
+	Variables map[string]string
+}
+
 type Data struct {
-	StackName string
-	APIDomain string
+	StackName                string
+	APIDomain                string
+	RouteSelectionExpression string
+	Authorizers              []AuthorizerData
+	CORS                     *CORSData
+	Throttling               *ThrottlingData
+	Routes                   []ThrottlingData
+	Stages                   []StageData
+	Tags                     map[string]string
 }
 
 type LambdaData struct {
-	Name        string
-	AsModule    bool
-	Source      string
-	RoleName    string
-	Runtime     string
-	StackName   string
-	Description string
-	Envars      map[string]string
-	Verb        string
-	Path        string
-	Files       map[string]generators.File
+	generators.FunctionData
+
+	Name         string
+	AsModule     bool
+	Source       string
+	RoleName     string
+	Runtime      string
+	Handler      string
+	StackName    string
+	APILabel     string
+	WebSocket    bool
+	Description  string
+	Envars       map[string]string
+	Dependencies []generators.Dependency
+	Verb         string
+	Path         string
+	Routes       []RouteData
+	Tags         map[string]string
+	Files        map[string]generators.File
+}
+
+// IntegrationData represents the routes of the API that are not served by a Lambda. The target is the URL of an HTTP
+// integration, or the label of the SQS queue or Kinesis stream of a service integration.
+type IntegrationData struct {
+	Name      string
+	Label     string
+	StackName string
+	Type      string
+	Target    string
+	Routes    []RouteData
+	Tags      map[string]string
 }
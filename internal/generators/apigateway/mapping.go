@@ -2,30 +2,85 @@ package apigateway
 
 import (
 	_ "embed"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 )
 
 const (
-	filenameTfAPIG   = "apig.tf"
-	filenameTfLambda = "lambda.tf"
-	filenameGoLambda = "lambda.go"
-	filenameGoMain   = "main.go"
+	filenameTfAPIG         = "apig.tf"
+	filenameTfLambda       = "lambda.tf"
+	filenameTfIntegration  = "integration.tf"
+	filenameTfWebSocket    = "websocket.tf"
+	filenameGoConfig       = "config.go"
+	filenameGoDependencies = "dependencies.go"
+	filenameGoLambda       = "lambda.go"
+	filenameGoLambdaTest   = "lambda_test.go"
+	filenameGoMain         = "main.go"
+	filenamePyLambda       = "lambda_function.py"
+	filenamePyRequirements = "requirements.txt"
+	filenameJsIndex        = "index.js"
+	filenameJsPackage      = "package.json"
 )
 
 var (
 	//go:embed tmpls/apig.tf.tmpl
 	tmplAPIGtf []byte
 
+	//go:embed tmpls/integration.tf.tmpl
+	tmplIntegrationTf []byte
+
+	//go:embed tmpls/websocket.tf.tmpl
+	tmplWebSocketTf []byte
+
+	//go:embed tmpls/config.go.tmpl
+	tmplConfigGo []byte
+
+	//go:embed tmpls/dependencies.go.tmpl
+	tmplDependenciesGo []byte
+
 	//go:embed tmpls/lambda.go.tmpl
 	tmplLambdaGo []byte
 
+	//go:embed tmpls/websocket_lambda.go.tmpl
+	tmplWebSocketLambdaGo []byte
+
+	//go:embed tmpls/lambda_test.go.tmpl
+	tmplLambdaTestGo []byte
+
 	//go:embed tmpls/lambda.tf.tmpl
 	tmplLambdaTf []byte
 
 	//go:embed tmpls/main.go.tmpl
 	tmplMainGo []byte
+
+	//go:embed tmpls/lambda_function.py.tmpl
+	tmplLambdaPy []byte
+
+	//go:embed tmpls/requirements.txt.tmpl
+	tmplRequirementsTxt []byte
+
+	//go:embed tmpls/index.js.tmpl
+	tmplIndexJs []byte
+
+	//go:embed tmpls/package.json.tmpl
+	tmplPackageJSON []byte
 )
 
-var defaultGoTemplateFiles = map[string]string{
-	filenameGoLambda: string(tmplLambdaGo),
-	filenameGoMain:   string(tmplMainGo),
+// Code templates by runtime family.
+var defaultCodeTemplateFiles = map[string]map[string]string{
+	generators.RuntimeGo: {
+		filenameGoConfig:       string(tmplConfigGo),
+		filenameGoDependencies: string(tmplDependenciesGo),
+		filenameGoLambda:       string(tmplLambdaGo),
+		filenameGoLambdaTest:   string(tmplLambdaTestGo),
+		filenameGoMain:         string(tmplMainGo),
+	},
+	generators.RuntimePython: {
+		filenamePyLambda:       string(tmplLambdaPy),
+		filenamePyRequirements: string(tmplRequirementsTxt),
+	},
+	generators.RuntimeNodeJS: {
+		filenameJsIndex:   string(tmplIndexJs),
+		filenameJsPackage: string(tmplPackageJSON),
+	},
 }
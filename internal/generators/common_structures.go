@@ -1,6 +1,36 @@
 package generators
 
+import "fmt"
+
 type File struct {
 	Tmpl    string
 	Imports []string
 }
+
+const (
+	// DependencySQS is the kind of a dependency on an SQS queue.
+	DependencySQS = "sqs"
+
+	// DependencyS3 is the kind of a dependency on an S3 bucket.
+	DependencyS3 = "s3"
+
+	// DependencyKinesis is the kind of a dependency on a Kinesis stream.
+	DependencyKinesis = "kinesis"
+)
+
+// Dependency represents a downstream resource of a Lambda, implied by one of its environment variables.
+type Dependency struct {
+	Name  string
+	Envar string
+	Kind  string
+}
+
+// Suffix returns the suffix that makes the names of the resources of the i-th item of a list unique, like the triggers
+// of a Lambda or the routes of an API. The first one has no suffix, so the resources of a single item keep their names.
+func Suffix(i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("_%d", i+1)
+}
@@ -1,22 +1,201 @@
 package config
 
+import "fmt"
+
+const (
+	// APIGatewayAuthorizerJWT validates the JSON Web Tokens of the requests against an issuer and an audience.
+	APIGatewayAuthorizerJWT = "JWT"
+
+	// APIGatewayAuthorizerRequest invokes a Lambda function to authorize the requests.
+	APIGatewayAuthorizerRequest = "REQUEST"
+)
+
+const (
+	// APIGatewayIntegrationHTTP proxies the requests to the URL of the target.
+	APIGatewayIntegrationHTTP = "HTTP"
+
+	// APIGatewayIntegrationSQS sends the body of the requests as messages to the SQS queue of the target.
+	APIGatewayIntegrationSQS = "SQS"
+
+	// APIGatewayIntegrationKinesis puts the body of the requests as records into the Kinesis stream of the target.
+	APIGatewayIntegrationKinesis = "KINESIS"
+)
+
+const (
+	// APIGatewayProtocolHTTP serves HTTP requests. It is the protocol of the API Gateways that do not set one.
+	APIGatewayProtocolHTTP = "HTTP"
+
+	// APIGatewayProtocolWebSocket keeps the connections of the clients open, routing their messages by the route
+	// selection expression.
+	APIGatewayProtocolWebSocket = "WEBSOCKET"
+)
+
+// APIGatewayDefaultRoute is the path of the route catching the requests that do not match any other route.
+const APIGatewayDefaultRoute = "$default"
+
+// APIGatewayProtocolTypes returns the protocols of an API Gateway.
+func APIGatewayProtocolTypes() []string {
+	return []string{APIGatewayProtocolHTTP, APIGatewayProtocolWebSocket}
+}
+
+// APIGatewayIntegrationTypes returns the types of the integrations of an API Gateway that are not Lambdas.
+func APIGatewayIntegrationTypes() []string {
+	return []string{APIGatewayIntegrationHTTP, APIGatewayIntegrationSQS, APIGatewayIntegrationKinesis}
+}
+
+// APIGatewayAuthorizerTypes returns the types of the authorizers of an API Gateway.
+func APIGatewayAuthorizerTypes() []string {
+	return []string{APIGatewayAuthorizerJWT, APIGatewayAuthorizerRequest}
+}
+
+// APIGatewayAuthorizer represents an authorizer of an API Gateway, attached to the routes that reference its name.
+type APIGatewayAuthorizer struct {
+	Name               string   `yaml:"name"`
+	Type               string   `yaml:"type"`
+	IdentitySources    []string `yaml:"identity_sources,omitempty"`
+	Issuer             string   `yaml:"issuer,omitempty"`
+	Audience           []string `yaml:"audience,omitempty"`
+	FunctionARN        string   `yaml:"function_arn,omitempty"`
+	ResultTTLInSeconds int      `yaml:"result_ttl_in_seconds,omitempty"`
+}
+
+// APIGatewayCORS represents the cross-origin resource sharing configuration of an API Gateway.
+type APIGatewayCORS struct {
+	AllowOrigins     []string `yaml:"allow_origins,omitempty"`
+	AllowMethods     []string `yaml:"allow_methods,omitempty"`
+	AllowHeaders     []string `yaml:"allow_headers,omitempty"`
+	ExposeHeaders    []string `yaml:"expose_headers,omitempty"`
+	AllowCredentials bool     `yaml:"allow_credentials,omitempty"`
+	MaxAge           int      `yaml:"max_age,omitempty"`
+}
+
+// IsEmpty returns true when no CORS setting is set.
+func (r *APIGatewayCORS) IsEmpty() bool {
+	return len(r.AllowOrigins) == 0 && len(r.AllowMethods) == 0 && len(r.AllowHeaders) == 0 &&
+		len(r.ExposeHeaders) == 0 && !r.AllowCredentials && r.MaxAge == 0
+}
+
+// APIGatewayThrottling represents the throttling limits of the stages or of a route of an API Gateway.
+type APIGatewayThrottling struct {
+	BurstLimit int     `yaml:"burst_limit,omitempty"`
+	RateLimit  float64 `yaml:"rate_limit,omitempty"`
+}
+
+// APIGatewayStage represents a named stage of an API Gateway, deployed along with the $default one.
+type APIGatewayStage struct {
+	Name      string            `yaml:"name"`
+	Variables map[string]string `yaml:"variables,omitempty"`
+}
+
+// APIGatewayRouteSettings represents the authorizer and the throttling limits of routes.
+type APIGatewayRouteSettings struct {
+	Authorizer          string               `yaml:"authorizer,omitempty"`
+	AuthorizationScopes []string             `yaml:"authorization_scopes,omitempty"`
+	Throttling          APIGatewayThrottling `yaml:"throttling,omitempty"`
+}
+
+// APIGatewayRoute represents a route of an API Gateway. The $default route and the routes of a WebSocket API are set
+// by their path, without a verb.
+type APIGatewayRoute struct {
+	APIGatewayRouteSettings `yaml:",inline"`
+
+	Verb string `yaml:"verb,omitempty"`
+	Path string `yaml:"path"`
+}
+
+// RouteKey returns the route key of the route: the verb and the path, or the path alone when there is no verb.
+func (r *APIGatewayRoute) RouteKey() string {
+	if r.Verb == "" {
+		return r.Path
+	}
+
+	return fmt.Sprintf("%s %s", r.Verb, r.Path)
+}
+
+// routesWithDefaults returns the routes, with the settings they do not set taken from the given settings.
+func routesWithDefaults(routes []APIGatewayRoute, settings *APIGatewayRouteSettings) []APIGatewayRoute {
+	result := make([]APIGatewayRoute, 0, len(routes))
+
+	for _, route := range routes {
+		if route.Authorizer == "" {
+			route.Authorizer = settings.Authorizer
+			route.AuthorizationScopes = settings.AuthorizationScopes
+		}
+
+		if route.Throttling == (APIGatewayThrottling{}) {
+			route.Throttling = settings.Throttling
+		}
+
+		result = append(result, route)
+	}
+
+	return result
+}
+
 type APIGatewayLambda struct {
+	LambdaFunction          `yaml:",inline"`
+	APIGatewayRouteSettings `yaml:",inline"`
+
 	Name        string            `yaml:"name"`
 	Source      string            `yaml:"source"`
 	RoleName    string            `yaml:"role_name,omitempty"`
 	Runtime     string            `yaml:"runtime,omitempty"`
 	Description string            `yaml:"description"`
 	Envars      map[string]string `yaml:"envars,omitempty"`
-	Verb        string            `yaml:"verb"`
-	Path        string            `yaml:"path"`
+	Verb        string            `yaml:"verb,omitempty"`
+	Path        string            `yaml:"path,omitempty"`
+	Routes      []APIGatewayRoute `yaml:"routes,omitempty"`
+	Tags        map[string]string `yaml:"tags,omitempty"`
 	Files       []File            `yaml:"files,omitempty"`
 }
 
 func (r *APIGatewayLambda) GetName() string { return r.Name }
 
+// GetRoutes returns the route set by the verb and the path, followed by the other routes of the Lambda. The routes
+// without an authorizer or throttling limits take the ones of the Lambda.
+func (r *APIGatewayLambda) GetRoutes() []APIGatewayRoute {
+	var routes []APIGatewayRoute
+
+	if r.Verb != "" || r.Path != "" {
+		routes = append(routes, APIGatewayRoute{Verb: r.Verb, Path: r.Path})
+	}
+
+	return routesWithDefaults(append(routes, r.Routes...), &r.APIGatewayRouteSettings)
+}
+
+// APIGatewayIntegration represents the routes of an API Gateway that are not served by a Lambda: an HTTP proxy to a
+// URL, a message sent to an SQS queue, or a record put into a Kinesis stream.
+type APIGatewayIntegration struct {
+	APIGatewayRouteSettings `yaml:",inline"`
+
+	Name   string            `yaml:"name"`
+	Type   string            `yaml:"type"`
+	Target string            `yaml:"target"`
+	Routes []APIGatewayRoute `yaml:"routes"`
+}
+
+// GetRoutes returns the routes of the integration. The routes without an authorizer or throttling limits take the
+// ones of the integration.
+func (r *APIGatewayIntegration) GetRoutes() []APIGatewayRoute {
+	return routesWithDefaults(r.Routes, &r.APIGatewayRouteSettings)
+}
+
 type APIGateway struct {
-	StackName string             `yaml:"stack_name"`
-	APIDomain string             `yaml:"api_domain"`
-	APIG      bool               `yaml:"apig"`
-	Lambdas   []APIGatewayLambda `yaml:"lambdas"`
+	StackName                string                  `yaml:"stack_name"`
+	APIDomain                string                  `yaml:"api_domain"`
+	APIG                     bool                    `yaml:"apig"`
+	ProtocolType             string                  `yaml:"protocol_type,omitempty"`
+	RouteSelectionExpression string                  `yaml:"route_selection_expression,omitempty"`
+	Authorizers              []APIGatewayAuthorizer  `yaml:"authorizers,omitempty"`
+	CORS                     APIGatewayCORS          `yaml:"cors,omitempty"`
+	Throttling               APIGatewayThrottling    `yaml:"throttling,omitempty"`
+	Stages                   []APIGatewayStage       `yaml:"stages,omitempty"`
+	Tags                     map[string]string       `yaml:"tags,omitempty"`
+	Lambdas                  []APIGatewayLambda      `yaml:"lambdas"`
+	Integrations             []APIGatewayIntegration `yaml:"integrations,omitempty"`
+}
+
+// IsWebSocket returns true when the API Gateway is a WebSocket API.
+func (r *APIGateway) IsWebSocket() bool {
+	return r.ProtocolType == APIGatewayProtocolWebSocket
 }
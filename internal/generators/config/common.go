@@ -6,7 +6,7 @@ type FilenameTemplateMap map[string]string
 
 type File struct {
 	Name    string   `yaml:"name"`
-	Tmpl    string   `yaml:"tmpl,omitempty"`
+	Tmpl    string   `yaml:"tmpl,omitempty" interpolate:"-"`
 	Imports []string `yaml:"imports,omitempty"`
 }
 
@@ -6,15 +6,22 @@ type Resource interface {
 
 // Config represents a configuration object that can be populated from a YAML file.
 type Config struct {
-	Draw                     Draw                     `yaml:"draw,omitempty"`
-	OverrideDefaultTemplates OverrideDefaultTemplates `yaml:"override_default_templates,omitempty"`
-	Diagram                  Diagram                  `yaml:"diagram,omitempty"`
-	Structure                Structure                `yaml:"structure,omitempty"`
-	APIGateways              []APIGateway             `yaml:"apigateways,omitempty"`
-	Kinesis                  []Kinesis                `yaml:"kinesis,omitempty"`
-	Lambdas                  []Lambda                 `yaml:"lambdas,omitempty"`
-	Buckets                  []S3                     `yaml:"buckets,omitempty"`
-	SNSs                     []SNS                    `yaml:"sns,omitempty"`
-	SQSs                     []SQS                    `yaml:"sqs,omitempty"`
-	RestfulAPIs              []RestfulAPI             `yaml:"restfulapis,omitempty"`
+	Include                  []string                  `yaml:"include,omitempty"`
+	Environments             map[string]map[string]any `yaml:"environments,omitempty"`
+	Vars                     map[string]string         `yaml:"vars,omitempty" interpolate:"-"`
+	AWSProviderVersion       int                       `yaml:"aws_provider_version,omitempty"`
+	Tags                     map[string]string         `yaml:"tags,omitempty"`
+	Draw                     Draw                      `yaml:"draw,omitempty"`
+	OverrideDefaultTemplates OverrideDefaultTemplates  `yaml:"override_default_templates,omitempty"`
+	Diagram                  Diagram                   `yaml:"diagram,omitempty"`
+	Structure                Structure                 `yaml:"structure,omitempty"`
+	APIGateways              []APIGateway              `yaml:"apigateways,omitempty"`
+	DynamoDBs                []DynamoDB                `yaml:"dynamodb,omitempty"`
+	EventBridges             []EventBridge             `yaml:"eventbridge,omitempty"`
+	Kinesis                  []Kinesis                 `yaml:"kinesis,omitempty"`
+	Lambdas                  []Lambda                  `yaml:"lambdas,omitempty"`
+	Buckets                  []S3                      `yaml:"buckets,omitempty"`
+	SNSs                     []SNS                     `yaml:"sns,omitempty"`
+	SQSs                     []SQS                     `yaml:"sqs,omitempty"`
+	RestfulAPIs              []RestfulAPI              `yaml:"restfulapis,omitempty"`
 }
@@ -6,7 +6,18 @@ type DriagramLambda struct {
 	Runtime  string `yaml:"runtime,omitempty"`
 }
 
+// DiagramAPIGateway represents the settings of the API Gateways found in a diagram. The authorizer, when set, is
+// attached to the routes of every Lambda of the API Gateways.
+type DiagramAPIGateway struct {
+	Authorizers []APIGatewayAuthorizer `yaml:"authorizers,omitempty"`
+	Authorizer  string                 `yaml:"authorizer,omitempty"`
+	CORS        APIGatewayCORS         `yaml:"cors,omitempty"`
+	Throttling  APIGatewayThrottling   `yaml:"throttling,omitempty"`
+	Stages      []APIGatewayStage      `yaml:"stages,omitempty"`
+}
+
 type Diagram struct {
-	StackName string         `yaml:"stack_name"`
-	Lambda    DriagramLambda `yaml:"lambda"`
+	StackName  string            `yaml:"stack_name"`
+	Lambda     DriagramLambda    `yaml:"lambda"`
+	APIGateway DiagramAPIGateway `yaml:"apigateway,omitempty"`
 }
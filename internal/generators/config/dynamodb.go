@@ -0,0 +1,37 @@
+package config
+
+// DynamoDBAttribute represents an attribute used as a key by the table or by one of its indexes.
+type DynamoDBAttribute struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+}
+
+// DynamoDBIndex represents a global secondary index of a DynamoDB table.
+type DynamoDBIndex struct {
+	Name             string   `yaml:"name"`
+	HashKey          string   `yaml:"hash_key"`
+	RangeKey         string   `yaml:"range_key,omitempty"`
+	ProjectionType   string   `yaml:"projection_type,omitempty"`
+	NonKeyAttributes []string `yaml:"non_key_attributes,omitempty"`
+	ReadCapacity     int      `yaml:"read_capacity,omitempty"`
+	WriteCapacity    int      `yaml:"write_capacity,omitempty"`
+}
+
+// DynamoDB represents the configuration for a DynamoDB table.
+type DynamoDB struct {
+	Name                   string              `yaml:"name"`
+	BillingMode            string              `yaml:"billing_mode,omitempty"`
+	ReadCapacity           int                 `yaml:"read_capacity,omitempty"`
+	WriteCapacity          int                 `yaml:"write_capacity,omitempty"`
+	HashKey                string              `yaml:"hash_key"`
+	RangeKey               string              `yaml:"range_key,omitempty"`
+	Attributes             []DynamoDBAttribute `yaml:"attributes,omitempty"`
+	GlobalSecondaryIndexes []DynamoDBIndex     `yaml:"global_secondary_indexes,omitempty"`
+	TTLAttribute           string              `yaml:"ttl_attribute,omitempty"`
+	PointInTimeRecovery    bool                `yaml:"point_in_time_recovery,omitempty"`
+	StreamViewType         string              `yaml:"stream_view_type,omitempty"`
+	Tags                   map[string]string   `yaml:"tags,omitempty"`
+	Files                  []File              `yaml:"files,omitempty"`
+}
+
+func (r *DynamoDB) GetName() string { return r.Name }
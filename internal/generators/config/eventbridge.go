@@ -0,0 +1,88 @@
+package config
+
+import "strings"
+
+const (
+	// EventBridgeDefaultBus is the name of the default event bus of the account, which is not created.
+	EventBridgeDefaultBus = "default"
+
+	// EventBridgeTargetLambda invokes a Lambda function.
+	EventBridgeTargetLambda = "LAMBDA"
+
+	// EventBridgeTargetSQS sends the event to an SQS queue.
+	EventBridgeTargetSQS = "SQS"
+
+	// EventBridgeTargetKinesis puts the event as a record on a Kinesis stream.
+	EventBridgeTargetKinesis = "KINESIS"
+
+	// EventBridgeTargetSNS publishes the event to an SNS topic.
+	EventBridgeTargetSNS = "SNS"
+
+	// EventBridgeTargetStepFunctions starts an execution of a Step Functions state machine.
+	EventBridgeTargetStepFunctions = "STEP_FUNCTIONS"
+)
+
+// EventBridgeTargetTypes returns the types of the targets of an EventBridge rule.
+func EventBridgeTargetTypes() []string {
+	return []string{
+		EventBridgeTargetLambda, EventBridgeTargetSQS, EventBridgeTargetKinesis, EventBridgeTargetSNS,
+		EventBridgeTargetStepFunctions,
+	}
+}
+
+// EventBridgeInputTransformer represents how the event is transformed before being sent to the target.
+type EventBridgeInputTransformer struct {
+	InputPaths    map[string]string `yaml:"input_paths,omitempty"`
+	InputTemplate string            `yaml:"input_template"`
+}
+
+// EventBridgeRetryPolicy represents how the events that cannot be delivered to the target are retried.
+type EventBridgeRetryPolicy struct {
+	MaximumEventAgeInSeconds int `yaml:"maximum_event_age_in_seconds,omitempty"`
+	MaximumRetryAttempts     int `yaml:"maximum_retry_attempts,omitempty"`
+}
+
+// EventBridgeTarget represents a resource receiving the events matched by a rule. The target and the dead-letter
+// queue are either the names of resources of the configuration, or Terraform expressions and ARNs of resources
+// managed elsewhere.
+type EventBridgeTarget struct {
+	Type             string                      `yaml:"type"`
+	Target           string                      `yaml:"target"`
+	MessageGroupID   string                      `yaml:"message_group_id,omitempty"`
+	InputTransformer EventBridgeInputTransformer `yaml:"input_transformer,omitempty"`
+	RetryPolicy      EventBridgeRetryPolicy      `yaml:"retry_policy,omitempty"`
+	DeadLetterQueue  string                      `yaml:"dead_letter_queue,omitempty"`
+}
+
+// EventBridgeRule represents a rule sending the events that match its pattern to its targets.
+type EventBridgeRule struct {
+	Name         string              `yaml:"name"`
+	Description  string              `yaml:"description,omitempty"`
+	EventPattern string              `yaml:"event_pattern"`
+	Targets      []EventBridgeTarget `yaml:"targets,omitempty"`
+}
+
+// EventBridge represents the configuration for an EventBridge event bus and its rules.
+type EventBridge struct {
+	Name  string            `yaml:"name"`
+	Rules []EventBridgeRule `yaml:"rules,omitempty"`
+	Tags  map[string]string `yaml:"tags,omitempty"`
+	Files []File            `yaml:"files,omitempty"`
+}
+
+func (r *EventBridge) GetName() string { return r.Name }
+
+// IsDefaultBus returns true when the rules belong to the default event bus of the account.
+func (r *EventBridge) IsDefaultBus() bool { return r.Name == EventBridgeDefaultBus }
+
+// IsReference returns true when the value is a Terraform expression or an ARN rather than the name of a resource of
+// the configuration.
+func IsReference(value string) bool {
+	for _, prefix := range []string{"arn:", "var.", "local.", "module.", "data.", "aws_"} {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+)
+
+const (
+	interpolateTag = "interpolate"
+	varsPrefix     = "var"
+	envPrefix      = "env"
+)
+
+// ErrUndefinedVariable represents a reference to a variable that is neither declared nor set in the environment.
+var ErrUndefinedVariable = errors.New("undefined variable")
+
+var (
+	osLookupEnv = os.LookupEnv
+
+	// Matches ${var.name} and ${env.NAME}. A leading $ escapes the reference, which is kept as ${...}.
+	interpolationRegex = regexp.MustCompile(`\$?\$\{(var|env)\.([A-Za-z0-9_.-]+)\}`)
+)
+
+// interpolator replaces the variable references of the string fields of the configuration.
+type interpolator struct {
+	vars      map[string]string
+	errs      []error
+	undefined map[string]struct{}
+}
+
+// interpolate replaces the ${var.name} and ${env.NAME} references of every string field of the configuration,
+// except the template contents, which usually hold Terraform interpolations of their own.
+func interpolate(config *Config, vars map[string]string) error {
+	i := &interpolator{vars: vars, undefined: map[string]struct{}{}}
+
+	i.value(reflect.ValueOf(config).Elem())
+
+	return errors.Join(i.errs...)
+}
+
+func (i *interpolator) value(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			i.value(v.Elem())
+		}
+	case reflect.Struct:
+		for j := 0; j < v.NumField(); j++ {
+			if v.Type().Field(j).Tag.Get(interpolateTag) != "-" && v.Type().Field(j).IsExported() {
+				i.value(v.Field(j))
+			}
+		}
+	case reflect.Slice:
+		for j := 0; j < v.Len(); j++ {
+			i.value(v.Index(j))
+		}
+	case reflect.Map:
+		if v.Type() == reflect.TypeOf(FilenameTemplateMap{}) {
+			return
+		}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			// Map values are not addressable, so they are copied, interpolated and set back.
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+
+			i.value(elem)
+
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.String:
+		v.SetString(i.string(v.String()))
+	}
+}
+
+func (i *interpolator) string(s string) string {
+	return interpolationRegex.ReplaceAllStringFunc(s, func(match string) string {
+		if match[1] == '$' {
+			return match[1:]
+		}
+
+		groups := interpolationRegex.FindStringSubmatch(match)
+		prefix, name := groups[1], groups[2]
+
+		var (
+			value string
+			ok    bool
+		)
+
+		switch prefix {
+		case varsPrefix:
+			value, ok = i.vars[name]
+		case envPrefix:
+			value, ok = osLookupEnv(name)
+		}
+
+		if !ok {
+			if _, reported := i.undefined[match]; !reported {
+				i.undefined[match] = struct{}{}
+				i.errs = append(i.errs, fmt.Errorf("%w: %s.%s", ErrUndefinedVariable, prefix, name))
+			}
+
+			return match
+		}
+
+		return value
+	})
+}
@@ -1,6 +1,55 @@
 package config
 
+const (
+	// LambdaArchitectureX86 is the x86_64 architecture of the Lambda functions, used by default.
+	LambdaArchitectureX86 = "x86_64"
+
+	// LambdaArchitectureARM is the arm64 architecture of the Lambda functions.
+	LambdaArchitectureARM = "arm64"
+)
+
+const (
+	// LambdaTracingModeActive samples and traces the invocations with X-Ray.
+	LambdaTracingModeActive = "Active"
+
+	// LambdaTracingModePassThrough only traces the invocations already sampled by the caller.
+	LambdaTracingModePassThrough = "PassThrough"
+)
+
+// LambdaVPC represents the subnets and security groups of a Lambda running in a VPC.
+type LambdaVPC struct {
+	SubnetIDs        []string `yaml:"subnet_ids,omitempty"`
+	SecurityGroupIDs []string `yaml:"security_group_ids,omitempty"`
+}
+
+// LambdaFunction represents the settings of the function, shared by the Lambdas and the Lambdas of the API Gateways.
+// Zero values keep the defaults of the provider.
+type LambdaFunction struct {
+	MemorySize                   int       `yaml:"memory_size,omitempty"`
+	Timeout                      int       `yaml:"timeout,omitempty"`
+	Architectures                []string  `yaml:"architectures,omitempty"`
+	EphemeralStorage             int       `yaml:"ephemeral_storage,omitempty"`
+	ReservedConcurrentExecutions int       `yaml:"reserved_concurrent_executions,omitempty"`
+	Layers                       []string  `yaml:"layers,omitempty"`
+	DeadLetterTargetARN          string    `yaml:"dead_letter_target_arn,omitempty"`
+	TracingMode                  string    `yaml:"tracing_mode,omitempty"`
+	VPC                          LambdaVPC `yaml:"vpc,omitempty"`
+	LogRetentionInDays           int       `yaml:"log_retention_in_days,omitempty"`
+}
+
+// LambdaArchitectures returns the architectures of the Lambda functions.
+func LambdaArchitectures() []string {
+	return []string{LambdaArchitectureX86, LambdaArchitectureARM}
+}
+
+// LambdaTracingModes returns the X-Ray tracing modes of the Lambda functions.
+func LambdaTracingModes() []string {
+	return []string{LambdaTracingModeActive, LambdaTracingModePassThrough}
+}
+
 type Lambda struct {
+	LambdaFunction `yaml:",inline"`
+
 	Name            string            `yaml:"name"`
 	Source          string            `yaml:"source"`
 	RoleName        string            `yaml:"role_name,omitempty"`
@@ -10,20 +59,80 @@ type Lambda struct {
 	KinesisTriggers []KinesisTrigger  `yaml:"kinesis-triggers,omitempty"`
 	SQSTriggers     []SQSTrigger      `yaml:"sqs-triggers,omitempty"`
 	Crons           []Cron            `yaml:"crons,omitempty"`
+	Tags            map[string]string `yaml:"tags,omitempty"`
 	Files           []File            `yaml:"files,omitempty"`
 }
 
 func (r *Lambda) GetName() string { return r.Name }
 
 type SQSTrigger struct {
-	SourceARN string `yaml:"source_arn"`
+	SourceARN                      string   `yaml:"source_arn"`
+	BatchSize                      int      `yaml:"batch_size,omitempty"`
+	MaximumBatchingWindowInSeconds int      `yaml:"maximum_batching_window_in_seconds,omitempty"`
+	MaximumConcurrency             int      `yaml:"maximum_concurrency,omitempty"`
+	FilterPatterns                 []string `yaml:"filter_patterns,omitempty"`
+	PartialBatchResponse           bool     `yaml:"partial_batch_response,omitempty"`
 }
 
+const (
+	// CronModeRule schedules the Lambda with a CloudWatch event rule, used by default.
+	CronModeRule = "rule"
+
+	// CronModeScheduler schedules the Lambda with an EventBridge Scheduler schedule, which supports time zones,
+	// flexible time windows, start and end dates and one-off at() expressions.
+	CronModeScheduler = "scheduler"
+)
+
+// CronModes returns the ways of scheduling a Lambda.
+func CronModes() []string {
+	return []string{CronModeRule, CronModeScheduler}
+}
+
+// Cron represents a schedule invoking a Lambda. The time zone, flexible time window and dates are only used by the
+// scheduler mode.
 type Cron struct {
 	ScheduleExpression string `yaml:"schedule_expression"`
 	IsEnabled          string `yaml:"is_enabled"`
+	Mode               string `yaml:"mode,omitempty"`
+	Timezone           string `yaml:"timezone,omitempty"`
+	FlexibleTimeWindow int    `yaml:"flexible_time_window,omitempty"`
+	StartDate          string `yaml:"start_date,omitempty"`
+	EndDate            string `yaml:"end_date,omitempty"`
+	Input              string `yaml:"input,omitempty"`
 }
 
+// IsScheduler returns true when the Lambda is scheduled by EventBridge Scheduler.
+func (c *Cron) IsScheduler() bool { return c.Mode == CronModeScheduler }
+
+const (
+	// KinesisStartingPositionLatest starts reading the stream from its most recent record.
+	KinesisStartingPositionLatest = "LATEST"
+
+	// KinesisStartingPositionTrimHorizon starts reading the stream from its oldest record.
+	KinesisStartingPositionTrimHorizon = "TRIM_HORIZON"
+)
+
 type KinesisTrigger struct {
-	SourceARN string `yaml:"source_arn"`
+	SourceARN                      string   `yaml:"source_arn"`
+	BatchSize                      int      `yaml:"batch_size,omitempty"`
+	StartingPosition               string   `yaml:"starting_position,omitempty"`
+	MaximumBatchingWindowInSeconds int      `yaml:"maximum_batching_window_in_seconds,omitempty"`
+	ParallelizationFactor          int      `yaml:"parallelization_factor,omitempty"`
+	BisectBatchOnFunctionError     bool     `yaml:"bisect_batch_on_function_error,omitempty"`
+	FilterPatterns                 []string `yaml:"filter_patterns,omitempty"`
+	PartialBatchResponse           bool     `yaml:"partial_batch_response,omitempty"`
+}
+
+// KinesisStartingPositions returns the positions a Lambda can start reading a stream from.
+func KinesisStartingPositions() []string {
+	return []string{KinesisStartingPositionLatest, KinesisStartingPositionTrimHorizon}
+}
+
+// GetStartingPosition returns the starting position of the trigger, LATEST by default.
+func (r *KinesisTrigger) GetStartingPosition() string {
+	if r.StartingPosition == "" {
+		return KinesisStartingPositionLatest
+	}
+
+	return r.StartingPosition
 }
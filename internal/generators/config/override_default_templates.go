@@ -1,10 +1,14 @@
 package config
 
 type OverrideDefaultTemplates struct {
-	APIGateway []FilenameTemplateMap `yaml:"apigateway,omitempty"`
-	Kinesis    []FilenameTemplateMap `yaml:"kinesis,omitempty"`
-	Lambda     []FilenameTemplateMap `yaml:"lambda,omitempty"`
-	S3Bucket   []FilenameTemplateMap `yaml:"bucket,omitempty"`
-	SNS        []FilenameTemplateMap `yaml:"sns,omitempty"`
-	SQS        []FilenameTemplateMap `yaml:"sqs,omitempty"`
+	APIGateway  []FilenameTemplateMap `yaml:"apigateway,omitempty"`
+	DynamoDB    []FilenameTemplateMap `yaml:"dynamodb,omitempty"`
+	EventBridge []FilenameTemplateMap `yaml:"eventbridge,omitempty"`
+	IAM         []FilenameTemplateMap `yaml:"iam,omitempty"`
+	Kinesis     []FilenameTemplateMap `yaml:"kinesis,omitempty"`
+	Lambda      []FilenameTemplateMap `yaml:"lambda,omitempty"`
+	Module      []FilenameTemplateMap `yaml:"module,omitempty"`
+	S3Bucket    []FilenameTemplateMap `yaml:"bucket,omitempty"`
+	SNS         []FilenameTemplateMap `yaml:"sns,omitempty"`
+	SQS         []FilenameTemplateMap `yaml:"sqs,omitempty"`
 }
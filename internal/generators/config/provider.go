@@ -0,0 +1,35 @@
+package config
+
+const (
+	// AWSProviderV3 generates the resource shapes of the 3.x AWS provider.
+	AWSProviderV3 = 3
+
+	// AWSProviderV5 generates the resource shapes of the 5.x AWS provider.
+	AWSProviderV5 = 5
+)
+
+// Version constraints of the AWS provider pinned in the required providers, by major version.
+var awsProviderConstraints = map[int]string{
+	AWSProviderV3: "~> 3.76",
+	AWSProviderV5: "~> 5.0",
+}
+
+// AWSProviderVersions returns the supported major versions of the AWS provider.
+func AWSProviderVersions() []int {
+	return []int{AWSProviderV3, AWSProviderV5}
+}
+
+// GetAWSProviderVersion returns the configured major version of the AWS provider. When it is not set, version 3 is
+// kept, so the generated Terraform does not change for the existing configurations.
+func (c *Config) GetAWSProviderVersion() int {
+	if c.AWSProviderVersion == 0 {
+		return AWSProviderV3
+	}
+
+	return c.AWSProviderVersion
+}
+
+// GetAWSProviderConstraint returns the version constraint of the AWS provider pinned in the required providers.
+func (c *Config) GetAWSProviderConstraint() string {
+	return awsProviderConstraints[c.GetAWSProviderVersion()]
+}
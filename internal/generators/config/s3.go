@@ -1,9 +1,10 @@
 package config
 
 type S3 struct {
-	Name           string `yaml:"name"`
-	ExpirationDays int    `yaml:"expiration-days,omitempty"`
-	Files          []File `yaml:"files,omitempty"`
+	Name           string            `yaml:"name"`
+	ExpirationDays int               `yaml:"expiration-days,omitempty"`
+	Tags           map[string]string `yaml:"tags,omitempty"`
+	Files          []File            `yaml:"files,omitempty"`
 }
 
 func (r *S3) GetName() string { return r.Name }
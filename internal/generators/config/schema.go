@@ -0,0 +1,210 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/diagram-code-generator/resources/pkg/parser/graphviz/dot"
+
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+const (
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaTitle = "AWS Terraform Generator configuration"
+)
+
+var (
+	// Values allowed by the named string types, used as enums.
+	enumsByType = map[reflect.Type][]string{
+		reflect.TypeOf(dot.DiagramDirection("")): {
+			string(dot.DirectionTopToBottom), string(dot.DirectionBottomToTop),
+			string(dot.DirectionLeftToRight), string(dot.DirectionRightToLeft),
+		},
+		reflect.TypeOf(dot.DiagramSpline("")): {
+			string(dot.SplineNone), string(dot.SplineCurved), string(dot.SplineLine), string(dot.SplineOrtho),
+			string(dot.SplinePolyline), string(dot.SplineSpline),
+		},
+		reflect.TypeOf(awsresources.ResourceType("")): resourceTypeKeys(),
+	}
+
+	// Schemas of the fields that accept more than their Go type describes.
+	fieldSchemas = map[reflect.Type]map[string]*JSONSchema{
+		reflect.TypeOf(APIGateway{}): {
+			"protocol_type": {Type: "string", Enum: APIGatewayProtocolTypes()},
+		},
+		reflect.TypeOf(APIGatewayAuthorizer{}): {
+			"type": {Type: "string", Enum: APIGatewayAuthorizerTypes()},
+		},
+		reflect.TypeOf(APIGatewayIntegration{}): {
+			"type": {Type: "string", Enum: APIGatewayIntegrationTypes()},
+		},
+		reflect.TypeOf(APIGatewayLambda{}): {"verb": {Type: "string", Enum: apiGatewayVerbs}},
+		reflect.TypeOf(APIGatewayRoute{}):  {"verb": {Type: "string", Enum: apiGatewayVerbs}},
+		reflect.TypeOf(Cron{}): {
+			"is_enabled": {Type: []string{"boolean", "string"}},
+			"mode":       {Type: "string", Enum: CronModes()},
+		},
+		reflect.TypeOf(EventBridgeTarget{}): {
+			"type": {Type: "string", Enum: EventBridgeTargetTypes()},
+		},
+		reflect.TypeOf(Kinesis{}): {"retention_period": {Type: []string{"integer", "string"}}},
+		reflect.TypeOf(LambdaFunction{}): {
+			"architectures": {
+				Type:  []string{"array", "null"},
+				Items: &JSONSchema{Type: "string", Enum: LambdaArchitectures()},
+			},
+			"tracing_mode": {Type: "string", Enum: LambdaTracingModes()},
+		},
+		reflect.TypeOf(KinesisTrigger{}): {
+			"starting_position": {Type: "string", Enum: KinesisStartingPositions()},
+		},
+		reflect.TypeOf(SNS{}): {
+			"mode": {Type: "string", Enum: []string{SNSModeTopic, SNSModeBucketNotification}},
+		},
+		reflect.TypeOf(SQSDLQ{}): {"redrive_permission": {Type: "string", Enum: SQSRedrivePermissions()}},
+	}
+
+	// Fields that must be set, following the validate command.
+	requiredFields = map[reflect.Type][]string{
+		reflect.TypeOf(APIGateway{}):                  {"stack_name"},
+		reflect.TypeOf(APIGatewayAuthorizer{}):        {"name", "type"},
+		reflect.TypeOf(APIGatewayIntegration{}):       {"name", "type", "target", "routes"},
+		reflect.TypeOf(APIGatewayLambda{}):            {"name"},
+		reflect.TypeOf(APIGatewayRoute{}):             {"path"},
+		reflect.TypeOf(APIGatewayStage{}):             {"name"},
+		reflect.TypeOf(Cron{}):                        {"schedule_expression"},
+		reflect.TypeOf(DynamoDB{}):                    {"name", "hash_key"},
+		reflect.TypeOf(EventBridge{}):                 {"name"},
+		reflect.TypeOf(EventBridgeInputTransformer{}): {"input_template"},
+		reflect.TypeOf(EventBridgeRule{}):             {"name", "event_pattern"},
+		reflect.TypeOf(EventBridgeTarget{}):           {"type", "target"},
+		reflect.TypeOf(Kinesis{}):                     {"name"},
+		reflect.TypeOf(KinesisTrigger{}):              {"source_arn"},
+		reflect.TypeOf(Lambda{}):                      {"name"},
+		reflect.TypeOf(S3{}):                          {"name"},
+		reflect.TypeOf(SNS{}):                         {"name"},
+		reflect.TypeOf(SQS{}):                         {"name"},
+		reflect.TypeOf(SQSTrigger{}):                  {"source_arn"},
+	}
+)
+
+// JSONSchema represents the subset of the JSON Schema vocabulary used to describe the configuration file.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	PropertyNames        *JSONSchema            `json:"propertyNames,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// NewJSONSchema returns the JSON Schema of the configuration file, generated from the Config struct tree. Every struct
+// is described once in the definitions and referenced from the fields that use it.
+func NewJSONSchema() *JSONSchema {
+	defs := map[string]*JSONSchema{}
+
+	root := structSchema(reflect.TypeOf(Config{}), defs)
+	root.Schema = jsonSchemaDraft
+	root.Title = jsonSchemaTitle
+	root.Defs = defs
+
+	return root
+}
+
+func typeSchema(typ reflect.Type, defs map[string]*JSONSchema) *JSONSchema {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if enum, ok := enumsByType[typ]; ok {
+		return &JSONSchema{Type: "string", Enum: enum}
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if _, ok := defs[typ.Name()]; !ok {
+			// Reserves the definition before describing the fields, so recursive structs reference themselves.
+			defs[typ.Name()] = &JSONSchema{}
+			*defs[typ.Name()] = *structSchema(typ, defs)
+		}
+
+		return &JSONSchema{Ref: "#/$defs/" + typ.Name()}
+	case reflect.Slice, reflect.Array:
+		// Lists and maps left empty in the YAML file are decoded as null.
+		return &JSONSchema{Type: []string{"array", "null"}, Items: typeSchema(typ.Elem(), defs)}
+	case reflect.Map:
+		schema := &JSONSchema{Type: []string{"object", "null"}, AdditionalProperties: typeSchema(typ.Elem(), defs)}
+
+		if enum, ok := enumsByType[typ.Key()]; ok {
+			schema.PropertyNames = &JSONSchema{Enum: enum}
+		}
+
+		return schema
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Interface:
+		return &JSONSchema{}
+	default:
+		return &JSONSchema{Type: "string"}
+	}
+}
+
+func structSchema(typ reflect.Type, defs map[string]*JSONSchema) *JSONSchema {
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           map[string]*JSONSchema{},
+		Required:             requiredFields[typ],
+		AdditionalProperties: false,
+	}
+
+	for name, fieldType := range yamlFields(typ) {
+		if fieldSchema := fieldSchemaOf(typ, name); fieldSchema != nil {
+			schema.Properties[name] = fieldSchema
+			continue
+		}
+
+		schema.Properties[name] = typeSchema(fieldType, defs)
+	}
+
+	return schema
+}
+
+// fieldSchemaOf returns the schema of the field declared for the struct or one of its inline structs, or nil when the
+// Go type of the field describes it.
+func fieldSchemaOf(typ reflect.Type, name string) *JSONSchema {
+	if fieldSchema, ok := fieldSchemas[typ][name]; ok {
+		return fieldSchema
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if fieldSchema := fieldSchemaOf(field.Type, name); fieldSchema != nil {
+				return fieldSchema
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceTypeKeys returns the resource types as they are written in the filters and images keys.
+func resourceTypeKeys() []string {
+	keys := make([]string, 0, len(awsresources.AvailableTypes))
+	for _, t := range awsresources.AvailableTypes {
+		keys = append(keys, strings.ToLower(t))
+	}
+
+	return keys
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJSONSchema(t *testing.T) {
+	schema := NewJSONSchema()
+
+	require.Equal(t, jsonSchemaDraft, schema.Schema)
+	require.Equal(t, &JSONSchema{Ref: "#/$defs/Draw"}, schema.Properties["draw"])
+	require.Equal(t, false, schema.AdditionalProperties)
+
+	draw := schema.Defs["Draw"]
+	require.Equal(t, []string{"TB", "BT", "LR", "RL"}, draw.Properties["direction"].Enum)
+	require.Equal(t, []string{
+		"apigateway", "cron", "database", "dynamodb", "endpoint", "eventbridge", "googlebq", "kinesis", "lambda",
+		"restfulapi", "s3", "sqs", "sns", "websocketapi",
+	}, draw.Properties["filters"].PropertyNames.Enum)
+	require.Equal(t, &JSONSchema{Ref: "#/$defs/Filter"}, draw.Properties["filters"].AdditionalProperties)
+
+	sqs := schema.Defs["SQS"]
+	require.Equal(t, []string{"name"}, sqs.Required)
+	require.Equal(t, &JSONSchema{Type: "integer"}, sqs.Properties["max_receive_count"])
+	require.Equal(t, &JSONSchema{Ref: "#/$defs/SQSDLQ"}, sqs.Properties["dlq"])
+	require.Equal(t, []string{"byQueue", "allowAll", "denyAll"},
+		schema.Defs["SQSDLQ"].Properties["redrive_permission"].Enum)
+
+	folder := schema.Defs["Folder"]
+	require.Equal(t, &JSONSchema{Ref: "#/$defs/Folder"}, folder.Properties["folders"].Items)
+
+	require.Equal(t, []string{"boolean", "string"}, schema.Defs["Cron"].Properties["is_enabled"].Type)
+
+	lambda := schema.Defs["Lambda"]
+	require.Equal(t, &JSONSchema{Type: "integer"}, lambda.Properties["memory_size"])
+	require.Equal(t, []string{"Active", "PassThrough"}, lambda.Properties["tracing_mode"].Enum)
+	require.Equal(t, lambda.Properties["tracing_mode"], schema.Defs["APIGatewayLambda"].Properties["tracing_mode"])
+	require.NotContains(t, schema.Defs, "LambdaFunction")
+}
+
+func TestNewJSONSchema_PublishedSchemaIsUpToDate(t *testing.T) {
+	published, err := os.ReadFile("../../../config.schema.json")
+	require.NoError(t, err)
+
+	data, err := json.MarshalIndent(NewJSONSchema(), "", "  ")
+	require.NoError(t, err)
+
+	require.Equal(t, string(published), string(data)+"\n",
+		"run 'aws-terraform-generator schema -o config.schema.json' to update the published schema")
+}
@@ -1,5 +1,13 @@
 package config
 
+const (
+	// SNSModeTopic creates an SNS topic with its subscriptions.
+	SNSModeTopic = "topic"
+
+	// SNSModeBucketNotification only wires S3 bucket notifications straight to the Lambdas and SQS queues.
+	SNSModeBucketNotification = "bucket_notification"
+)
+
 // SNSResource represents a Lambda function or SQS configuration.
 type SNSResource struct {
 	Name         string   `yaml:"name"`
@@ -8,13 +16,42 @@ type SNSResource struct {
 	FilterSuffix string   `yaml:"filter_suffix,omitempty"`
 }
 
+// SNSSubscription represents a subscription to an SNS topic.
+type SNSSubscription struct {
+	Protocol           string `yaml:"protocol"`
+	Endpoint           string `yaml:"endpoint"`
+	FilterPolicy       string `yaml:"filter_policy,omitempty"`
+	FilterPolicyScope  string `yaml:"filter_policy_scope,omitempty"`
+	RawMessageDelivery bool   `yaml:"raw_message_delivery,omitempty"`
+}
+
 // SNS represents the configuration for SNS (Simple Notification Service).
 type SNS struct {
-	Name       string        `yaml:"name"`
-	BucketName string        `yaml:"bucket_name"`
-	Lambdas    []SNSResource `yaml:"lambdas,omitempty"`
-	SQSs       []SNSResource `yaml:"sqs,omitempty"`
-	Files      []File        `yaml:"files,omitempty"`
+	Name           string            `yaml:"name"`
+	Mode           string            `yaml:"mode,omitempty"`
+	BucketName     string            `yaml:"bucket_name,omitempty"`
+	BucketEvents   []string          `yaml:"bucket_events,omitempty"`
+	FIFO           bool              `yaml:"fifo,omitempty"`
+	KMSMasterKeyID string            `yaml:"kms_master_key_id,omitempty"`
+	Subscriptions  []SNSSubscription `yaml:"subscriptions,omitempty"`
+	Lambdas        []SNSResource     `yaml:"lambdas,omitempty"`
+	SQSs           []SNSResource     `yaml:"sqs,omitempty"`
+	Tags           map[string]string `yaml:"tags,omitempty"`
+	Files          []File            `yaml:"files,omitempty"`
 }
 
 func (r *SNS) GetName() string { return r.Name }
+
+// GetMode returns the configured mode. When it is not set, the bucket notification mode is kept for configurations
+// that only declare lambdas or sqs, otherwise the topic mode is used.
+func (r *SNS) GetMode() string {
+	if r.Mode != "" {
+		return r.Mode
+	}
+
+	if len(r.Subscriptions) == 0 && (len(r.Lambdas) > 0 || len(r.SQSs) > 0) {
+		return SNSModeBucketNotification
+	}
+
+	return SNSModeTopic
+}
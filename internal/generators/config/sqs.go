@@ -1,9 +1,41 @@
 package config
 
+const (
+	// SQSRedrivePermissionByQueue only allows the queue to use the DLQ as its dead-letter queue.
+	SQSRedrivePermissionByQueue = "byQueue"
+
+	// SQSRedrivePermissionAllowAll allows every queue to use the DLQ as its dead-letter queue.
+	SQSRedrivePermissionAllowAll = "allowAll"
+
+	// SQSRedrivePermissionDenyAll does not allow any queue to use the DLQ as its dead-letter queue.
+	SQSRedrivePermissionDenyAll = "denyAll"
+)
+
+// SQSRedrivePermissions returns the permissions of the redrive allow policy of a DLQ.
+func SQSRedrivePermissions() []string {
+	return []string{SQSRedrivePermissionByQueue, SQSRedrivePermissionAllowAll, SQSRedrivePermissionDenyAll}
+}
+
+// SQSDLQ represents the dead-letter queue created along with an SQS queue.
+type SQSDLQ struct {
+	Disabled                bool   `yaml:"disabled,omitempty"`
+	MessageRetentionSeconds int    `yaml:"message_retention_seconds,omitempty"`
+	RedrivePermission       string `yaml:"redrive_permission,omitempty"`
+}
+
 type SQS struct {
-	Name            string `yaml:"name"`
-	MaxReceiveCount int32  `yaml:"max_receive_count"`
-	Files           []File `yaml:"files,omitempty"`
+	Name                     string            `yaml:"name"`
+	MaxReceiveCount          int32             `yaml:"max_receive_count,omitempty"`
+	FIFO                     bool              `yaml:"fifo,omitempty"`
+	VisibilityTimeoutSeconds int               `yaml:"visibility_timeout_seconds,omitempty"`
+	MessageRetentionSeconds  int               `yaml:"message_retention_seconds,omitempty"`
+	DelaySeconds             int               `yaml:"delay_seconds,omitempty"`
+	ReceiveWaitTimeSeconds   int               `yaml:"receive_wait_time_seconds,omitempty"`
+	SQSManagedSSE            bool              `yaml:"sqs_managed_sse,omitempty"`
+	KMSMasterKeyID           string            `yaml:"kms_master_key_id,omitempty"`
+	DLQ                      SQSDLQ            `yaml:"dlq,omitempty"`
+	Tags                     map[string]string `yaml:"tags,omitempty"`
+	Files                    []File            `yaml:"files,omitempty"`
 }
 
 func (r *SQS) GetName() string { return r.Name }
@@ -15,4 +15,5 @@ type Stack struct {
 type Structure struct {
 	Stacks           []Stack               `yaml:"stacks"`
 	DefaultTemplates []FilenameTemplateMap `yaml:"default_templates,omitempty"`
+	DefaultTags      bool                  `yaml:"default_tags,omitempty"`
 }
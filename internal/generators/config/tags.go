@@ -0,0 +1,30 @@
+package config
+
+import "maps"
+
+// ResourceTags returns the tags of a resource, merging the tags of the configuration root with the given ones, from
+// the least to the most specific: a later map overrides the keys of the previous ones. When the tags of the root are
+// emitted as the default_tags of the provider, they are not repeated on every resource.
+func (c *Config) ResourceTags(tags ...map[string]string) map[string]string {
+	merged := map[string]string{}
+
+	if !c.Structure.DefaultTags {
+		maps.Copy(merged, c.Tags)
+	}
+
+	for _, t := range tags {
+		maps.Copy(merged, t)
+	}
+
+	return merged
+}
+
+// DefaultTags returns the tags emitted as the default_tags of the provider, or nil when they are rendered on every
+// resource instead.
+func (c *Config) DefaultTags() map[string]string {
+	if !c.Structure.DefaultTags {
+		return nil
+	}
+
+	return c.Tags
+}
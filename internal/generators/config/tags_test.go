@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ResourceTags(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          Config
+		tags            []map[string]string
+		want            map[string]string
+		wantDefaultTags map[string]string
+	}{
+		{
+			name:   "no tags",
+			config: Config{},
+			want:   map[string]string{},
+		},
+		{
+			name:   "the most specific tags override the previous ones",
+			config: Config{Tags: map[string]string{"team": "payments", "env": "dev"}},
+			tags: []map[string]string{
+				{"team": "api", "owner": "alice"},
+				{"owner": "bob"},
+			},
+			want: map[string]string{"team": "api", "env": "dev", "owner": "bob"},
+		},
+		{
+			name: "root tags are left to the default tags of the provider",
+			config: Config{
+				Tags:      map[string]string{"team": "payments"},
+				Structure: Structure{DefaultTags: true},
+			},
+			tags:            []map[string]string{{"owner": "bob"}},
+			want:            map[string]string{"owner": "bob"},
+			wantDefaultTags: map[string]string{"team": "payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.config.ResourceTags(tt.tags...))
+			require.Equal(t, tt.wantDefaultTags, tt.config.DefaultTags())
+		})
+	}
+}
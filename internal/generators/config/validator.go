@@ -0,0 +1,1021 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// The time zones of the schedules are checked without relying on the time zone database of the system.
+	_ "time/tzdata"
+
+	"github.com/ettle/strcase"
+	"gopkg.in/yaml.v3"
+
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+const (
+	// Kinesis retention period range, in hours.
+	minKinesisRetentionPeriod = 24
+	maxKinesisRetentionPeriod = 8760
+
+	// Number of fields of an AWS cron expression.
+	cronExpressionFields = 6
+
+	// Lambda function ranges: memory and ephemeral storage in MB, timeout in seconds.
+	minLambdaMemorySize       = 128
+	maxLambdaMemorySize       = 10240
+	minLambdaTimeout          = 1
+	maxLambdaTimeout          = 900
+	minLambdaEphemeralStorage = 512
+	maxLambdaEphemeralStorage = 10240
+
+	// Stage deployed for every API Gateway.
+	defaultAPIGatewayStage = "$default"
+
+	// EventBridge retry policy ranges, in seconds and attempts.
+	minEventBridgeMaximumEventAge      = 60
+	maxEventBridgeMaximumEventAge      = 86400
+	maxEventBridgeMaximumRetryAttempts = 185
+
+	// Flexible time window of the schedules, in minutes.
+	minCronFlexibleTimeWindow = 1
+	maxCronFlexibleTimeWindow = 1440
+
+	// Layout of the date and time of the one-off at() schedule expressions.
+	atExpressionLayout = "2006-01-02T15:04:05"
+
+	yamlMergeKey = "<<"
+	yamlNullTag  = "!!null"
+	yamlIntTag   = "!!int"
+)
+
+var (
+	rateExpressionRegex = regexp.MustCompile(`^rate\(([0-9]+) (minute|minutes|hour|hours|day|days)\)$`)
+	cronExpressionRegex = regexp.MustCompile(`^cron\((.+)\)$`)
+	atExpressionRegex   = regexp.MustCompile(`^at\((.+)\)$`)
+
+	apiGatewayVerbs = []string{"ANY", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"}
+
+	// Retention periods accepted by CloudWatch log groups, in days.
+	logRetentionInDays = []int{
+		1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653,
+	}
+)
+
+// Issue represents a problem found in the configuration file, along with its position.
+type Issue struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, i.Message)
+}
+
+// Validator checks the configuration file for unknown fields, missing required fields, invalid values and
+// references to resources that are not defined. The files it includes are checked as well, along with the overlays of
+// their environments.
+type Validator struct {
+	yaml *YAML
+
+	// fileName is the file of the document being checked.
+	fileName string
+	// overlayFiles maps the nodes of the environment overlays to their files. When set, only the issues found in these
+	// nodes are reported.
+	overlayFiles map[*yaml.Node]string
+	issues       []Issue
+}
+
+func NewValidator(fileName string, opts ...YAMLOption) *Validator {
+	return &Validator{yaml: NewYAML(fileName, opts...)}
+}
+
+// Validate returns every issue found in the configuration file and in the files it includes, sorted by file and
+// position. When an environment is set, the values of its overlay are checked once merged into the configuration. The
+// error is only returned when a file cannot be read or is not a valid YAML document, or when the configuration cannot
+// be loaded with the environment and variables set.
+func (v *Validator) Validate() ([]Issue, error) {
+	var (
+		fileNames []string
+		documents []*yaml.Node
+	)
+
+	root, err := v.yaml.load(v.yaml.fileName, map[string]struct{}{}, func(fileName string, node *yaml.Node) {
+		fileNames = append(fileNames, fileName)
+		documents = append(documents, node)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v.issues = nil
+
+	if root == nil {
+		return nil, nil
+	}
+
+	// References may point to resources declared in the included files or in the overlay.
+	merged, err := v.yaml.Parse()
+	if errors.Is(err, ErrUnknownEnvironment) || errors.Is(err, ErrUndefinedVariable) {
+		return nil, err
+	}
+
+	overlayFiles := map[*yaml.Node]string{}
+
+	for i, document := range documents {
+		v.fileName = fileNames[i]
+
+		v.checkNode(document, reflect.TypeOf(Config{}))
+
+		if environments := mappingValue(document, environmentsKey); environments != nil {
+			for j := 1; j < len(environments.Content); j += 2 {
+				overlay := environments.Content[j]
+
+				v.checkNode(overlay, reflect.TypeOf(Config{}))
+				addNodeFiles(overlayFiles, overlay, v.fileName)
+			}
+		}
+
+		v.checkDocument(document, merged)
+	}
+
+	if v.yaml.environment != "" {
+		overlay := mappingValue(mappingValue(root, environmentsKey), v.yaml.environment)
+		if overlay == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEnvironment, v.yaml.environment)
+		}
+
+		v.overlayFiles = overlayFiles
+		v.checkDocument(mergeNodes(withoutKeys(root, includeKey, environmentsKey), overlay), merged)
+		v.overlayFiles = nil
+	}
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		if v.issues[i].File != v.issues[j].File {
+			return slices.Index(fileNames, v.issues[i].File) < slices.Index(fileNames, v.issues[j].File)
+		}
+
+		if v.issues[i].Line != v.issues[j].Line {
+			return v.issues[i].Line < v.issues[j].Line
+		}
+
+		return v.issues[i].Column < v.issues[j].Column
+	})
+
+	return v.issues, nil
+}
+
+// checkDocument checks the values of the document. The references are taken from the whole configuration, falling back
+// to the document itself when it cannot be loaded.
+func (v *Validator) checkDocument(document *yaml.Node, references *Config) {
+	// Values that cannot be decoded have already been reported, so the partially decoded config is enough.
+	var config Config
+	_ = document.Decode(&config)
+
+	if references == nil {
+		references = &config
+	}
+
+	v.checkAWSProviderVersion(document, config.AWSProviderVersion)
+	v.checkAPIGateways(document, config.APIGateways, references.SQSs, references.Kinesis)
+	v.checkKinesis(document, config.Kinesis)
+	v.checkLambdas(document, config.Lambdas, references.SQSs, references.Kinesis, references.GetAWSProviderVersion())
+	v.checkBuckets(document, config.Buckets)
+	v.checkDynamoDBs(document, config.DynamoDBs)
+	v.checkEventBridges(document, config.EventBridges, references)
+	v.checkSNSs(document, config.SNSs, references.Buckets)
+	v.checkSQSs(document, config.SQSs)
+}
+
+func (v *Validator) addIssue(node *yaml.Node, format string, args ...any) {
+	fileName := v.fileName
+
+	if v.overlayFiles != nil {
+		var ok bool
+
+		// The other values have already been checked in their own files.
+		if fileName, ok = v.overlayFiles[node]; !ok {
+			return
+		}
+	}
+
+	v.issues = append(v.issues, Issue{
+		File:    fileName,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// addNodeFiles maps the node and all its descendants to the file they are declared in.
+func addNodeFiles(nodeFiles map[*yaml.Node]string, node *yaml.Node, fileName string) {
+	nodeFiles[node] = fileName
+
+	for _, child := range node.Content {
+		addNodeFiles(nodeFiles, child, fileName)
+	}
+}
+
+// checkNode walks the node tree along with the Go type it is decoded into, reporting unknown fields and values that
+// cannot be decoded.
+func (v *Validator) checkNode(node *yaml.Node, typ reflect.Type) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if node.Kind == yaml.AliasNode || node.Tag == yamlNullTag {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.addIssue(node, "expected a mapping for %s", typ.Name())
+			return
+		}
+
+		fields := yamlFields(typ)
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+
+			if key.Value == yamlMergeKey {
+				continue
+			}
+
+			field, ok := fields[key.Value]
+			if !ok {
+				v.addIssue(key, "unknown field %q in %s", key.Value, typ.Name())
+				continue
+			}
+
+			v.checkNode(value, field)
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.addIssue(node, "expected a sequence")
+			return
+		}
+
+		for _, item := range node.Content {
+			v.checkNode(item, typ.Elem())
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.addIssue(node, "expected a mapping")
+			return
+		}
+
+		for i := 1; i < len(node.Content); i += 2 {
+			v.checkNode(node.Content[i], typ.Elem())
+		}
+	default:
+		if err := node.Decode(reflect.New(typ).Interface()); err != nil {
+			v.addIssue(node, "invalid value %q: expected %s", node.Value, typ.Kind())
+		}
+	}
+}
+
+func (v *Validator) checkAPIGateways(document *yaml.Node, apiGateways []APIGateway, sqss []SQS, kinesis []Kinesis) {
+	sqsNames := make(map[string]struct{}, len(sqss))
+	for i := range sqss {
+		sqsNames[sqss[i].Name] = struct{}{}
+	}
+
+	kinesisNames := make(map[string]struct{}, len(kinesis))
+	for i := range kinesis {
+		kinesisNames[kinesis[i].Name] = struct{}{}
+	}
+
+	for i := range apiGateways {
+		apiGateway := apiGateways[i]
+		node := nodeAt(document, "apigateways", i)
+
+		if apiGateway.StackName == "" {
+			v.addIssue(node, "apigateways[%d]: missing required field \"stack_name\"", i)
+		}
+
+		authorizers := v.checkAPIGatewayAuthorizers(node, i, apiGateway.Authorizers)
+
+		checkRoute := v.checkAPIGatewayRoute
+
+		switch apiGateway.ProtocolType {
+		case "", APIGatewayProtocolHTTP:
+			if apiGateway.RouteSelectionExpression != "" {
+				v.addIssue(nodeAt(node, "route_selection_expression"),
+					"apigateways[%d]: route_selection_expression is only used by %s APIs", i,
+					APIGatewayProtocolWebSocket)
+			}
+		case APIGatewayProtocolWebSocket:
+			v.checkWebSocketAPIGateway(node, i, &apiGateway)
+
+			checkRoute = v.checkWebSocketRoute
+		default:
+			v.addIssue(nodeAt(node, "protocol_type"), "apigateways[%d]: invalid protocol_type %q, expected one of %s",
+				i, apiGateway.ProtocolType, strings.Join(APIGatewayProtocolTypes(), ", "))
+		}
+
+		for j, stage := range apiGateway.Stages {
+			stageNode := nodeAt(node, "stages", j)
+
+			switch stage.Name {
+			case "":
+				v.addIssue(stageNode, "apigateways[%d].stages[%d]: missing required field \"name\"", i, j)
+			case defaultAPIGatewayStage:
+				v.addIssue(nodeAt(stageNode, "name"), "apigateways[%d].stages[%d]: stage %q is always deployed",
+					i, j, stage.Name)
+			}
+		}
+
+		for j := range apiGateway.Lambdas {
+			lambda := apiGateway.Lambdas[j]
+			lambdaNode := nodeAt(node, "lambdas", j)
+			subject := fmt.Sprintf("lambda %q", lambda.Name)
+
+			v.checkName(lambdaNode, fmt.Sprintf("apigateways[%d].lambdas[%d]", i, j), lambda.Name)
+			v.checkLambdaFunction(lambdaNode, lambda.Name, &lambda.LambdaFunction)
+			v.checkAPIGatewayAuthorizer(lambdaNode, subject, lambda.Authorizer, authorizers)
+
+			// The verb and the path are required unless the routes are listed.
+			if lambda.Verb != "" || lambda.Path != "" || len(lambda.Routes) == 0 {
+				checkRoute(lambdaNode, subject, &APIGatewayRoute{Verb: lambda.Verb, Path: lambda.Path}, authorizers)
+			}
+
+			for k := range lambda.Routes {
+				checkRoute(nodeAt(lambdaNode, "routes", k), subject, &lambda.Routes[k], authorizers)
+			}
+		}
+
+		for j := range apiGateway.Integrations {
+			v.checkAPIGatewayIntegration(nodeAt(node, "integrations", j), fmt.Sprintf("apigateways[%d]", i),
+				j, &apiGateway.Integrations[j], authorizers, sqsNames, kinesisNames)
+		}
+	}
+}
+
+// checkAPIGatewayIntegration reports the integrations without a valid type or target, and their invalid routes. The
+// queues and the streams of the service integrations must be defined in the configuration.
+func (v *Validator) checkAPIGatewayIntegration(
+	node *yaml.Node, path string, i int, integration *APIGatewayIntegration, authorizers, sqsNames,
+	kinesisNames map[string]struct{},
+) {
+	subject := fmt.Sprintf("integration %q", integration.Name)
+
+	v.checkName(node, fmt.Sprintf("%s.integrations[%d]", path, i), integration.Name)
+	v.checkAPIGatewayAuthorizer(node, subject, integration.Authorizer, authorizers)
+
+	switch integration.Type {
+	case "":
+		v.addIssue(node, "%s: missing required field \"type\"", subject)
+	case APIGatewayIntegrationHTTP, APIGatewayIntegrationSQS, APIGatewayIntegrationKinesis:
+	default:
+		v.addIssue(nodeAt(node, "type"), "%s: invalid type %q, expected one of %s", subject, integration.Type,
+			strings.Join(APIGatewayIntegrationTypes(), ", "))
+	}
+
+	var targetNames map[string]struct{}
+
+	switch integration.Type {
+	case APIGatewayIntegrationSQS:
+		targetNames = sqsNames
+	case APIGatewayIntegrationKinesis:
+		targetNames = kinesisNames
+	}
+
+	if _, ok := targetNames[integration.Target]; integration.Target == "" {
+		v.addIssue(node, "%s: missing required field \"target\"", subject)
+	} else if targetNames != nil && !ok {
+		v.addIssue(nodeAt(node, "target"), "%s: target %q is not defined in %s", subject, integration.Target,
+			strings.ToLower(integration.Type))
+	}
+
+	if len(integration.Routes) == 0 {
+		v.addIssue(node, "%s: missing required field \"routes\"", subject)
+	}
+
+	for j := range integration.Routes {
+		v.checkAPIGatewayRoute(nodeAt(node, "routes", j), subject, &integration.Routes[j], authorizers)
+	}
+}
+
+// checkAPIGatewayRoute reports the routes without a valid verb or path, and the authorizers they refer to that are not
+// defined. The $default route does not take a verb.
+func (v *Validator) checkAPIGatewayRoute(
+	node *yaml.Node, subject string, route *APIGatewayRoute, authorizers map[string]struct{},
+) {
+	v.checkAPIGatewayAuthorizer(node, subject, route.Authorizer, authorizers)
+
+	if route.Path == APIGatewayDefaultRoute {
+		if route.Verb != "" {
+			v.addIssue(nodeAt(node, "verb"), "%s: route %q does not take a verb", subject, route.Path)
+		}
+
+		return
+	}
+
+	switch {
+	case route.Verb == "":
+		v.addIssue(node, "%s: missing required field \"verb\"", subject)
+	case !slices.Contains(apiGatewayVerbs, route.Verb):
+		v.addIssue(nodeAt(node, "verb"), "%s: invalid verb %q, expected one of %s", subject, route.Verb,
+			strings.Join(apiGatewayVerbs, ", "))
+	}
+
+	switch {
+	case route.Path == "":
+		v.addIssue(node, "%s: missing required field \"path\"", subject)
+	case !strings.HasPrefix(route.Path, "/"):
+		v.addIssue(nodeAt(node, "path"), "%s: path %q must start with \"/\"", subject, route.Path)
+	}
+}
+
+// checkWebSocketAPIGateway reports the settings of a WebSocket API that only HTTP APIs support.
+func (v *Validator) checkWebSocketAPIGateway(node *yaml.Node, i int, apiGateway *APIGateway) {
+	unsupported := map[string]bool{
+		"api_domain":   apiGateway.APIDomain != "",
+		"authorizers":  len(apiGateway.Authorizers) > 0,
+		"cors":         !apiGateway.CORS.IsEmpty(),
+		"integrations": len(apiGateway.Integrations) > 0,
+	}
+
+	for _, field := range []string{"api_domain", "authorizers", "cors", "integrations"} {
+		if unsupported[field] {
+			v.addIssue(nodeAt(node, field), "apigateways[%d]: %s is not supported by %s APIs", i, field,
+				APIGatewayProtocolWebSocket)
+		}
+	}
+}
+
+// checkWebSocketRoute reports the routes of a WebSocket API without a route key, set by the path, and the routes that
+// set a verb.
+func (v *Validator) checkWebSocketRoute(
+	node *yaml.Node, subject string, route *APIGatewayRoute, _ map[string]struct{},
+) {
+	if route.Verb != "" {
+		v.addIssue(nodeAt(node, "verb"), "%s: route %q does not take a verb", subject, route.Path)
+	}
+
+	if route.Path == "" {
+		v.addIssue(node, "%s: missing required field \"path\"", subject)
+	}
+}
+
+// checkAPIGatewayAuthorizer reports the authorizer when it is not defined in the authorizers of the API Gateway.
+func (v *Validator) checkAPIGatewayAuthorizer(
+	node *yaml.Node, subject, authorizer string, authorizers map[string]struct{},
+) {
+	if _, ok := authorizers[authorizer]; authorizer != "" && !ok {
+		v.addIssue(nodeAt(node, "authorizer"), "%s: authorizer %q is not defined in authorizers", subject, authorizer)
+	}
+}
+
+// checkAPIGatewayAuthorizers reports the authorizers missing the settings required by their type, and returns the
+// names of the authorizers.
+func (v *Validator) checkAPIGatewayAuthorizers(
+	node *yaml.Node, apiGatewayIndex int, authorizers []APIGatewayAuthorizer,
+) map[string]struct{} {
+	names := make(map[string]struct{}, len(authorizers))
+
+	for i := range authorizers {
+		authorizer := authorizers[i]
+		authorizerNode := nodeAt(node, "authorizers", i)
+
+		v.checkName(authorizerNode, fmt.Sprintf("apigateways[%d].authorizers[%d]", apiGatewayIndex, i),
+			authorizer.Name)
+
+		names[authorizer.Name] = struct{}{}
+
+		switch authorizer.Type {
+		case "":
+			v.addIssue(authorizerNode, "authorizer %q: missing required field \"type\"", authorizer.Name)
+		case APIGatewayAuthorizerJWT:
+			if authorizer.Issuer == "" {
+				v.addIssue(authorizerNode, "authorizer %q: missing required field \"issuer\"", authorizer.Name)
+			}
+
+			if len(authorizer.Audience) == 0 {
+				v.addIssue(authorizerNode, "authorizer %q: missing required field \"audience\"", authorizer.Name)
+			}
+		case APIGatewayAuthorizerRequest:
+			if authorizer.FunctionARN == "" {
+				v.addIssue(authorizerNode, "authorizer %q: missing required field \"function_arn\"", authorizer.Name)
+			}
+		default:
+			v.addIssue(nodeAt(authorizerNode, "type"), "authorizer %q: invalid type %q, expected one of %s",
+				authorizer.Name, authorizer.Type, strings.Join(APIGatewayAuthorizerTypes(), ", "))
+		}
+	}
+
+	return names
+}
+
+func (v *Validator) checkAWSProviderVersion(document *yaml.Node, version int) {
+	if version == 0 || slices.Contains(AWSProviderVersions(), version) {
+		return
+	}
+
+	v.addIssue(nodeAt(document, "aws_provider_version"), "invalid aws_provider_version %d, expected %d or %d",
+		version, AWSProviderV3, AWSProviderV5)
+}
+
+func (v *Validator) checkKinesis(document *yaml.Node, kinesis []Kinesis) {
+	for i := range kinesis {
+		node := nodeAt(document, "kinesis", i)
+
+		v.checkName(node, fmt.Sprintf("kinesis[%d]", i), kinesis[i].Name)
+
+		if kinesis[i].RetentionPeriod == "" {
+			continue
+		}
+
+		retentionPeriod, err := strconv.Atoi(kinesis[i].RetentionPeriod)
+		if err != nil || retentionPeriod < minKinesisRetentionPeriod || retentionPeriod > maxKinesisRetentionPeriod {
+			v.addIssue(nodeAt(node, "retention_period"),
+				"kinesis %q: retention_period %q must be a number of hours between %d and %d",
+				kinesis[i].Name, kinesis[i].RetentionPeriod, minKinesisRetentionPeriod, maxKinesisRetentionPeriod)
+		}
+	}
+}
+
+func (v *Validator) checkLambdas(
+	document *yaml.Node, lambdas []Lambda, sqss []SQS, kinesis []Kinesis, awsProviderVersion int,
+) {
+	sqsLabels := map[string]struct{}{}
+	for i := range sqss {
+		sqsLabels[resourceLabel(sqss[i].Name, awsresources.SQSType)] = struct{}{}
+	}
+
+	kinesisLabels := map[string]struct{}{}
+	for i := range kinesis {
+		kinesisLabels[resourceLabel(kinesis[i].Name, awsresources.KinesisType)] = struct{}{}
+	}
+
+	for i := range lambdas {
+		lambda := lambdas[i]
+		node := nodeAt(document, "lambdas", i)
+
+		v.checkName(node, fmt.Sprintf("lambdas[%d]", i), lambda.Name)
+		v.checkLambdaFunction(node, lambda.Name, &lambda.LambdaFunction)
+
+		for j := range lambda.Crons {
+			v.checkCron(nodeAt(node, "crons", j), lambda.Name, &lambda.Crons[j], awsProviderVersion)
+		}
+
+		for j, trigger := range lambda.SQSTriggers {
+			v.checkTriggerReference(nodeAt(node, "sqs-triggers", j), lambda.Name, trigger.SourceARN,
+				awsresources.LabelAWSSQSQueue, sqsLabels)
+		}
+
+		for j, trigger := range lambda.KinesisTriggers {
+			triggerNode := nodeAt(node, "kinesis-triggers", j)
+
+			v.checkTriggerReference(triggerNode, lambda.Name, trigger.SourceARN, awsresources.LabelAWSKinesisStream,
+				kinesisLabels)
+
+			if trigger.StartingPosition != "" && !slices.Contains(KinesisStartingPositions(), trigger.StartingPosition) {
+				v.addIssue(nodeAt(triggerNode, "starting_position"),
+					"lambda %q: invalid starting_position %q, expected one of %s", lambda.Name,
+					trigger.StartingPosition, strings.Join(KinesisStartingPositions(), ", "))
+			}
+		}
+	}
+}
+
+func (v *Validator) checkLambdaFunction(node *yaml.Node, lambdaName string, function *LambdaFunction) {
+	v.checkLambdaRange(node, lambdaName, "memory_size", function.MemorySize, minLambdaMemorySize, maxLambdaMemorySize)
+	v.checkLambdaRange(node, lambdaName, "timeout", function.Timeout, minLambdaTimeout, maxLambdaTimeout)
+	v.checkLambdaRange(node, lambdaName, "ephemeral_storage", function.EphemeralStorage, minLambdaEphemeralStorage,
+		maxLambdaEphemeralStorage)
+
+	switch {
+	case len(function.Architectures) > 1:
+		v.addIssue(nodeAt(node, "architectures"), "lambda %q: architectures must have a single architecture",
+			lambdaName)
+	case len(function.Architectures) == 1 && !slices.Contains(LambdaArchitectures(), function.Architectures[0]):
+		v.addIssue(nodeAt(node, "architectures", 0), "lambda %q: invalid architecture %q, expected one of %s",
+			lambdaName, function.Architectures[0], strings.Join(LambdaArchitectures(), ", "))
+	}
+
+	if function.TracingMode != "" && !slices.Contains(LambdaTracingModes(), function.TracingMode) {
+		v.addIssue(nodeAt(node, "tracing_mode"), "lambda %q: invalid tracing_mode %q, expected one of %s",
+			lambdaName, function.TracingMode, strings.Join(LambdaTracingModes(), ", "))
+	}
+
+	if function.LogRetentionInDays != 0 && !slices.Contains(logRetentionInDays, function.LogRetentionInDays) {
+		v.addIssue(nodeAt(node, "log_retention_in_days"),
+			"lambda %q: log_retention_in_days %d is not a retention period supported by CloudWatch Logs",
+			lambdaName, function.LogRetentionInDays)
+	}
+}
+
+// checkCron reports the invalid schedules. The one-off at() expressions and the options of EventBridge Scheduler are
+// only available in the scheduler mode, which needs the 5.x AWS provider.
+func (v *Validator) checkCron(node *yaml.Node, lambdaName string, cron *Cron, awsProviderVersion int) {
+	switch {
+	case cron.Mode != "" && !slices.Contains(CronModes(), cron.Mode):
+		v.addIssue(nodeAt(node, "mode"), "lambda %q: invalid mode %q, expected one of %s", lambdaName, cron.Mode,
+			strings.Join(CronModes(), ", "))
+	case cron.IsScheduler() && awsProviderVersion != AWSProviderV5:
+		v.addIssue(nodeAt(node, "mode"), "lambda %q: the %s mode requires aws_provider_version %d", lambdaName,
+			CronModeScheduler, AWSProviderV5)
+	}
+
+	switch {
+	case cron.ScheduleExpression == "":
+		v.addIssue(node, "lambda %q: missing required field \"schedule_expression\"", lambdaName)
+	case isValidScheduleExpression(cron.ScheduleExpression):
+	case !atExpressionRegex.MatchString(cron.ScheduleExpression):
+		expected := "rate(<value> <unit>) or cron(<6 fields>)"
+		if cron.IsScheduler() {
+			expected = "rate(<value> <unit>), cron(<6 fields>) or at(yyyy-mm-ddThh:mm:ss)"
+		}
+
+		v.addIssue(nodeAt(node, "schedule_expression"), "lambda %q: invalid schedule_expression %q, expected %s",
+			lambdaName, cron.ScheduleExpression, expected)
+	case !cron.IsScheduler():
+		v.addIssue(nodeAt(node, "schedule_expression"), "lambda %q: at() schedule_expression requires the %s mode",
+			lambdaName, CronModeScheduler)
+	case !isValidDateTime(atExpressionRegex.FindStringSubmatch(cron.ScheduleExpression)[1], atExpressionLayout):
+		v.addIssue(nodeAt(node, "schedule_expression"),
+			"lambda %q: invalid schedule_expression %q, expected at(yyyy-mm-ddThh:mm:ss)", lambdaName,
+			cron.ScheduleExpression)
+	}
+
+	switch cron.IsEnabled {
+	case "", "true", "false":
+	default:
+		if !IsReference(cron.IsEnabled) {
+			v.addIssue(nodeAt(node, "is_enabled"),
+				"lambda %q: invalid is_enabled %q, expected true, false or a Terraform expression", lambdaName,
+				cron.IsEnabled)
+		}
+	}
+
+	if cron.Input != "" && !json.Valid([]byte(cron.Input)) {
+		v.addIssue(nodeAt(node, "input"), "lambda %q: input is not a valid JSON document", lambdaName)
+	}
+
+	if !cron.IsScheduler() {
+		for field, set := range map[string]bool{
+			"timezone": cron.Timezone != "", "flexible_time_window": cron.FlexibleTimeWindow != 0,
+			"start_date": cron.StartDate != "", "end_date": cron.EndDate != "",
+		} {
+			if set {
+				v.addIssue(nodeAt(node, field), "lambda %q: %s is only used by the %s mode", lambdaName, field,
+					CronModeScheduler)
+			}
+		}
+
+		return
+	}
+
+	if _, err := time.LoadLocation(cron.Timezone); cron.Timezone != "" && err != nil {
+		v.addIssue(nodeAt(node, "timezone"), "lambda %q: invalid timezone %q", lambdaName, cron.Timezone)
+	}
+
+	v.checkLambdaRange(node, lambdaName, "flexible_time_window", cron.FlexibleTimeWindow, minCronFlexibleTimeWindow,
+		maxCronFlexibleTimeWindow)
+
+	for field, value := range map[string]string{"start_date": cron.StartDate, "end_date": cron.EndDate} {
+		if value != "" && !isValidDateTime(value, time.RFC3339) {
+			v.addIssue(nodeAt(node, field), "lambda %q: invalid %s %q, expected an RFC 3339 date and time",
+				lambdaName, field, value)
+		}
+	}
+}
+
+// checkLambdaRange reports the integer field when it is set outside of its range.
+func (v *Validator) checkLambdaRange(node *yaml.Node, lambdaName, field string, value, minValue, maxValue int) {
+	if value != 0 && (value < minValue || value > maxValue) {
+		v.addIssue(nodeAt(node, field), "lambda %q: %s %d must be between %d and %d", lambdaName, field, value,
+			minValue, maxValue)
+	}
+}
+
+// checkTriggerReference reports Terraform references to queues and streams that are not defined in the
+// configuration. Literal ARNs may point to resources managed elsewhere, so they are not checked.
+func (v *Validator) checkTriggerReference(
+	node *yaml.Node, lambdaName, sourceARN, resourceType string, labels map[string]struct{},
+) {
+	if sourceARN == "" {
+		v.addIssue(node, "lambda %q: missing required field \"source_arn\"", lambdaName)
+		return
+	}
+
+	arn := awsresources.ParseResourceARN(sourceARN, awsresources.UnknownType)
+	if arn.Label == "" || arn.Type != resourceType {
+		return
+	}
+
+	if _, ok := labels[arn.Label]; !ok {
+		v.addIssue(nodeAt(node, "source_arn"), "lambda %q: source_arn %q references an undefined %s",
+			lambdaName, sourceARN, resourceType)
+	}
+}
+
+func (v *Validator) checkSNSs(document *yaml.Node, snss []SNS, buckets []S3) {
+	bucketNames := make(map[string]struct{}, len(buckets))
+	for i := range buckets {
+		bucketNames[buckets[i].Name] = struct{}{}
+	}
+
+	for i := range snss {
+		node := nodeAt(document, "sns", i)
+
+		v.checkName(node, fmt.Sprintf("sns[%d]", i), snss[i].Name)
+
+		if snss[i].BucketName == "" {
+			continue
+		}
+
+		if _, ok := bucketNames[snss[i].BucketName]; !ok {
+			v.addIssue(nodeAt(node, "bucket_name"), "sns %q: bucket_name %q is not defined in buckets",
+				snss[i].Name, snss[i].BucketName)
+		}
+	}
+}
+
+func (v *Validator) checkSQSs(document *yaml.Node, sqss []SQS) {
+	for i := range sqss {
+		node := nodeAt(document, "sqs", i)
+
+		v.checkName(node, fmt.Sprintf("sqs[%d]", i), sqss[i].Name)
+
+		v.checkSQSDLQ(node, &sqss[i])
+	}
+}
+
+func (v *Validator) checkSQSDLQ(node *yaml.Node, sqs *SQS) {
+	if sqs.DLQ.Disabled {
+		return
+	}
+
+	maxReceiveCountNode := nodeAt(node, "max_receive_count")
+
+	switch {
+	case maxReceiveCountNode == node:
+		v.addIssue(node, "sqs %q: missing required field \"max_receive_count\"", sqs.Name)
+	case maxReceiveCountNode.Tag == yamlIntTag && sqs.MaxReceiveCount <= 0:
+		v.addIssue(maxReceiveCountNode, "sqs %q: max_receive_count must be greater than zero", sqs.Name)
+	}
+
+	if sqs.DLQ.RedrivePermission != "" && !slices.Contains(SQSRedrivePermissions(), sqs.DLQ.RedrivePermission) {
+		v.addIssue(nodeAt(node, "dlq", "redrive_permission"), "sqs %q: invalid redrive_permission %q, expected one of %s",
+			sqs.Name, sqs.DLQ.RedrivePermission, strings.Join(SQSRedrivePermissions(), ", "))
+	}
+}
+
+func (v *Validator) checkBuckets(document *yaml.Node, buckets []S3) {
+	for i := range buckets {
+		v.checkName(nodeAt(document, "buckets", i), fmt.Sprintf("buckets[%d]", i), buckets[i].Name)
+	}
+}
+
+func (v *Validator) checkDynamoDBs(document *yaml.Node, dynamoDBs []DynamoDB) {
+	for i := range dynamoDBs {
+		node := nodeAt(document, "dynamodb", i)
+
+		v.checkName(node, fmt.Sprintf("dynamodb[%d]", i), dynamoDBs[i].Name)
+
+		if dynamoDBs[i].HashKey == "" {
+			v.addIssue(node, "dynamodb %q: missing required field \"hash_key\"", dynamoDBs[i].Name)
+		}
+	}
+}
+
+func (v *Validator) checkEventBridges(document *yaml.Node, eventBridges []EventBridge, references *Config) {
+	namesByType := map[string]map[string]struct{}{
+		EventBridgeTargetLambda:  {},
+		EventBridgeTargetSQS:     {},
+		EventBridgeTargetKinesis: {},
+		EventBridgeTargetSNS:     {},
+	}
+
+	for i := range references.Lambdas {
+		namesByType[EventBridgeTargetLambda][references.Lambdas[i].Name] = struct{}{}
+	}
+
+	for i := range references.APIGateways {
+		for j := range references.APIGateways[i].Lambdas {
+			namesByType[EventBridgeTargetLambda][references.APIGateways[i].Lambdas[j].Name] = struct{}{}
+		}
+	}
+
+	for i := range references.SQSs {
+		namesByType[EventBridgeTargetSQS][references.SQSs[i].Name] = struct{}{}
+	}
+
+	for i := range references.Kinesis {
+		namesByType[EventBridgeTargetKinesis][references.Kinesis[i].Name] = struct{}{}
+	}
+
+	for i := range references.SNSs {
+		namesByType[EventBridgeTargetSNS][references.SNSs[i].Name] = struct{}{}
+	}
+
+	for i := range eventBridges {
+		node := nodeAt(document, "eventbridge", i)
+
+		v.checkName(node, fmt.Sprintf("eventbridge[%d]", i), eventBridges[i].Name)
+
+		for j := range eventBridges[i].Rules {
+			rule := eventBridges[i].Rules[j]
+			ruleNode := nodeAt(node, "rules", j)
+			subject := fmt.Sprintf("rule %q", rule.Name)
+
+			v.checkName(ruleNode, fmt.Sprintf("eventbridge[%d].rules[%d]", i, j), rule.Name)
+
+			var pattern map[string]any
+
+			switch {
+			case rule.EventPattern == "":
+				v.addIssue(ruleNode, "%s: missing required field \"event_pattern\"", subject)
+			case json.Unmarshal([]byte(rule.EventPattern), &pattern) != nil:
+				v.addIssue(nodeAt(ruleNode, "event_pattern"), "%s: event_pattern is not a valid JSON object", subject)
+			}
+
+			for k := range rule.Targets {
+				v.checkEventBridgeTarget(nodeAt(ruleNode, "targets", k), subject, &rule.Targets[k], namesByType)
+			}
+		}
+	}
+}
+
+// checkEventBridgeTarget reports the targets without a valid type or target. The targets given by name must be
+// defined in the configuration, while the state machines are always given by their ARN or a Terraform expression.
+func (v *Validator) checkEventBridgeTarget(
+	node *yaml.Node, subject string, target *EventBridgeTarget, namesByType map[string]map[string]struct{},
+) {
+	switch target.Type {
+	case "":
+		v.addIssue(node, "%s: missing required field \"type\"", subject)
+	case EventBridgeTargetLambda, EventBridgeTargetSQS, EventBridgeTargetKinesis, EventBridgeTargetSNS,
+		EventBridgeTargetStepFunctions:
+	default:
+		v.addIssue(nodeAt(node, "type"), "%s: invalid type %q, expected one of %s", subject, target.Type,
+			strings.Join(EventBridgeTargetTypes(), ", "))
+	}
+
+	names, hasNames := namesByType[target.Type]
+
+	switch _, ok := names[target.Target]; {
+	case target.Target == "":
+		v.addIssue(node, "%s: missing required field \"target\"", subject)
+	case IsReference(target.Target):
+	case target.Type == EventBridgeTargetStepFunctions:
+		v.addIssue(nodeAt(node, "target"), "%s: target %q of a %s target must be an ARN or a Terraform expression",
+			subject, target.Target, EventBridgeTargetStepFunctions)
+	case hasNames && !ok:
+		v.addIssue(nodeAt(node, "target"), "%s: target %q is not defined in %s", subject, target.Target,
+			eventBridgeTargetSection(target.Type))
+	}
+
+	if target.MessageGroupID != "" && target.Type != EventBridgeTargetSQS {
+		v.addIssue(nodeAt(node, "message_group_id"), "%s: message_group_id is only used by %s targets", subject,
+			EventBridgeTargetSQS)
+	}
+
+	if len(target.InputTransformer.InputPaths) > 0 && target.InputTransformer.InputTemplate == "" {
+		v.addIssue(nodeAt(node, "input_transformer"), "%s: missing required field \"input_template\"", subject)
+	}
+
+	retryPolicy := target.RetryPolicy
+
+	if age := retryPolicy.MaximumEventAgeInSeconds; age != 0 &&
+		(age < minEventBridgeMaximumEventAge || age > maxEventBridgeMaximumEventAge) {
+		v.addIssue(nodeAt(node, "retry_policy", "maximum_event_age_in_seconds"),
+			"%s: maximum_event_age_in_seconds %d must be between %d and %d", subject, age,
+			minEventBridgeMaximumEventAge, maxEventBridgeMaximumEventAge)
+	}
+
+	if attempts := retryPolicy.MaximumRetryAttempts; attempts < 0 || attempts > maxEventBridgeMaximumRetryAttempts {
+		v.addIssue(nodeAt(node, "retry_policy", "maximum_retry_attempts"),
+			"%s: maximum_retry_attempts %d must be between 0 and %d", subject, attempts,
+			maxEventBridgeMaximumRetryAttempts)
+	}
+
+	if _, ok := namesByType[EventBridgeTargetSQS][target.DeadLetterQueue]; target.DeadLetterQueue != "" &&
+		!IsReference(target.DeadLetterQueue) && !ok {
+		v.addIssue(nodeAt(node, "dead_letter_queue"), "%s: dead_letter_queue %q is not defined in sqs", subject,
+			target.DeadLetterQueue)
+	}
+}
+
+func (v *Validator) checkName(node *yaml.Node, path, name string) {
+	if name == "" {
+		v.addIssue(node, "%s: missing required field \"name\"", path)
+	}
+}
+
+// nodeAt returns the node found by following the mapping keys and sequence indexes of the path. When part of the path
+// is missing, the deepest node found is returned, so issues about missing fields point to their parent.
+func nodeAt(node *yaml.Node, path ...any) *yaml.Node {
+	for _, step := range path {
+		next := childNode(node, step)
+		if next == nil {
+			return node
+		}
+
+		node = next
+	}
+
+	return node
+}
+
+func childNode(node *yaml.Node, step any) *yaml.Node {
+	switch s := step.(type) {
+	case string:
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == s {
+				return node.Content[i+1]
+			}
+		}
+	case int:
+		if node.Kind == yaml.SequenceNode && s < len(node.Content) {
+			return node.Content[s]
+		}
+	}
+
+	return nil
+}
+
+// eventBridgeTargetSection returns the section of the configuration declaring the targets of the type.
+func eventBridgeTargetSection(targetType string) string {
+	if targetType == EventBridgeTargetLambda {
+		return "lambdas"
+	}
+
+	return strings.ToLower(targetType)
+}
+
+// resourceLabel returns the Terraform label of the resource, following the generators naming.
+func resourceLabel(name string, resType awsresources.ResourceType) string {
+	return fmt.Sprintf("%s_%s", strcase.ToSnake(name), awsresources.SuffixByResource[resType])
+}
+
+// yamlFields returns the types of the struct fields by their YAML keys, including the fields of the inline structs.
+func yamlFields(typ reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, typ.NumField())
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+
+		// The fields of an inline struct are keys of the struct embedding it.
+		if options == "inline" && field.Type.Kind() == reflect.Struct {
+			maps.Copy(fields, yamlFields(field.Type))
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields[name] = field.Type
+	}
+
+	return fields
+}
+
+func isValidDateTime(value, layout string) bool {
+	_, err := time.Parse(layout, value)
+
+	return err == nil
+}
+
+func isValidScheduleExpression(expression string) bool {
+	if matches := rateExpressionRegex.FindStringSubmatch(expression); matches != nil {
+		value, err := strconv.Atoi(matches[1])
+		if err != nil || value <= 0 {
+			return false
+		}
+
+		singular := !strings.HasSuffix(matches[2], "s")
+
+		return (value == 1) == singular
+	}
+
+	if matches := cronExpressionRegex.FindStringSubmatch(expression); matches != nil {
+		return len(strings.Fields(matches[1])) == cronExpressionFields
+	}
+
+	return false
+}
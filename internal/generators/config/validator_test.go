@@ -0,0 +1,168 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	invalidFile := testdataFolder + "/validate.config.yaml"
+	includeFile := testdataFolder + "/validate.include.config.yaml"
+	includedFile := testdataFolder + "/validate.include.shared.config.yaml"
+
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        []YAMLOption
+		want        []Issue
+		expectedErr bool
+	}{
+		{
+			name:     "valid config",
+			fileName: testdataFolder + "/validate.config.valid.yaml",
+		},
+		{
+			name:     "every issue with its position",
+			fileName: invalidFile,
+			want: []Issue{
+				{invalidFile, 4, 9, `authorizer "cognito": missing required field "issuer"`},
+				{invalidFile, 4, 9, `authorizer "cognito": missing required field "audience"`},
+				{invalidFile, 8, 15, `lambda "ordersAPI": invalid verb "FETCH", expected one of ` +
+					`ANY, DELETE, GET, HEAD, OPTIONS, PATCH, POST, PUT`},
+				{invalidFile, 9, 15, `lambda "ordersAPI": path "v1/orders" must start with "/"`},
+				{invalidFile, 10, 21, `lambda "ordersAPI": authorizer "okta" is not defined in authorizers`},
+				{invalidFile, 11, 9, `lambda "missingRoute": missing required field "verb"`},
+				{invalidFile, 11, 9, `lambda "missingRoute": missing required field "path"`},
+				{invalidFile, 14, 19, `lambda "fallback": route "$default" does not take a verb`},
+				{invalidFile, 17, 9, `integration "ordersQueue": missing required field "routes"`},
+				{invalidFile, 19, 17, `integration "ordersQueue": target "missing" is not defined in sqs`},
+				{invalidFile, 23, 7, `apigateways[1]: cors is not supported by WEBSOCKET APIs`},
+				{invalidFile, 27, 15, `lambda "connect": route "$connect" does not take a verb`},
+				{invalidFile, 31, 23, `kinesis "orders": retention_period "12" must be a number of hours ` +
+					`between 24 and 8760`},
+				{invalidFile, 34, 14, `lambda "orderProcessor": timeout 1000 must be between 1 and 900`},
+				{invalidFile, 37, 28, `lambda "orderProcessor": invalid starting_position "EARLIEST", expected ` +
+					`one of LATEST, TRIM_HORIZON`},
+				{invalidFile, 39, 21, `lambda "orderProcessor": source_arn "aws_sqs_queue.undefined_sqs.arn" ` +
+					`references an undefined aws_sqs_queue`},
+				{invalidFile, 42, 30, `lambda "orderProcessor": invalid schedule_expression "rate(5 minute)", ` +
+					`expected rate(<value> <unit>) or cron(<6 fields>)`},
+				{invalidFile, 44, 30, `lambda "orderProcessor": at() schedule_expression requires the scheduler mode`},
+				{invalidFile, 45, 21, `lambda "orderProcessor": invalid is_enabled "maybe", expected true, false or a ` +
+					`Terraform expression`},
+				{invalidFile, 46, 19, `lambda "orderProcessor": timezone is only used by the scheduler mode`},
+				{invalidFile, 47, 30, `lambda "orderProcessor": invalid schedule_expression "at(2030-13-01T00:00:00)", ` +
+					`expected at(yyyy-mm-ddThh:mm:ss)`},
+				{invalidFile, 48, 15, `lambda "orderProcessor": the scheduler mode requires aws_provider_version 5`},
+				{invalidFile, 49, 19, `lambda "orderProcessor": invalid timezone "Mars/Olympus"`},
+				{invalidFile, 50, 31, `lambda "orderProcessor": flexible_time_window 2000 must be between 1 and 1440`},
+				{invalidFile, 51, 21, `lambda "orderProcessor": invalid start_date "tomorrow", expected an RFC 3339 ` +
+					`date and time`},
+				{invalidFile, 52, 16, `lambda "orderProcessor": input is not a valid JSON document`},
+				{invalidFile, 57, 18, `sns "reportEvents": bucket_name "missing" is not defined in buckets`},
+				{invalidFile, 59, 5, `sqs "target": missing required field "max_receive_count"`},
+				{invalidFile, 60, 5, `unknown field "max_recieve_count" in SQS`},
+				{invalidFile, 61, 5, `sqs[1]: missing required field "name"`},
+				{invalidFile, 61, 24, `invalid value "many": expected int32`},
+				{invalidFile, 65, 27, `sqs "orders": invalid redrive_permission "byTopic", expected one of ` +
+					`byQueue, allowAll, denyAll`},
+				{invalidFile, 70, 24, `rule "orderCreated": event_pattern is not a valid JSON object`},
+				{invalidFile, 73, 21, `rule "orderCreated": target "missing" is not defined in lambdas`},
+				{invalidFile, 74, 31, `rule "orderCreated": message_group_id is only used by SQS targets`},
+				{invalidFile, 76, 21, `rule "orderCreated": target "shipOrder" of a STEP_FUNCTIONS target must be an ` +
+					`ARN or a Terraform expression`},
+				{invalidFile, 80, 45, `rule "orderCreated": maximum_event_age_in_seconds 30 must be between 60 ` +
+					`and 86400`},
+				{invalidFile, 81, 32, `rule "orderCreated": dead_letter_queue "missingDLQ" is not defined in sqs`},
+				{invalidFile, 82, 9, `rule "orderShipped": missing required field "event_pattern"`},
+				{invalidFile, 84, 19, `rule "orderShipped": invalid type "EMAIL", expected one of LAMBDA, SQS, ` +
+					`KINESIS, SNS, STEP_FUNCTIONS`},
+				{invalidFile, 86, 23, `invalid aws_provider_version 4, expected 3 or 5`},
+			},
+		},
+		{
+			name:     "included files and overlays with their own file names",
+			fileName: includeFile,
+			want: []Issue{
+				{includeFile, 14, 9, `unknown field "memroy_size" in Lambda`},
+				{includedFile, 4, 5, `unknown field "visibility" in SQS`},
+			},
+		},
+		{
+			name:     "values of the overlay of the environment",
+			fileName: includeFile,
+			opts:     []YAMLOption{WithEnvironment("prd")},
+			want: []Issue{
+				{includeFile, 13, 18, `lambda "orderProcessor": timeout 1000 must be between 1 and 900`},
+				{includeFile, 14, 9, `unknown field "memroy_size" in Lambda`},
+				{includedFile, 4, 5, `unknown field "visibility" in SQS`},
+			},
+		},
+		{
+			name:        "unknown environment",
+			fileName:    includeFile,
+			opts:        []YAMLOption{WithEnvironment("qa")},
+			expectedErr: true,
+		},
+		{
+			name:     "references resolved with the variables set",
+			fileName: testdataFolder + "/validate.vars.config.yaml",
+			opts:     []YAMLOption{WithVars(map[string]string{"source": "./build"})},
+		},
+		{
+			name:        "undefined variable",
+			fileName:    testdataFolder + "/validate.vars.config.yaml",
+			expectedErr: true,
+		},
+		{
+			name:        "file does not exist",
+			fileName:    "fileDoesNotExist.yaml",
+			expectedErr: true,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewValidator(tc.fileName, tc.opts...).Validate()
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{File: "diagram.yaml", Line: 3, Column: 7, Message: `unknown field "nme" in SQS`}
+
+	require.Equal(t, `diagram.yaml:3:7: unknown field "nme" in SQS`, issue.String())
+}
+
+func Test_isValidScheduleExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{expression: "rate(1 minute)", want: true},
+		{expression: "rate(5 minutes)", want: true},
+		{expression: "rate(1 days)", want: false},
+		{expression: "rate(0 hours)", want: false},
+		{expression: "cron(0 1 * * ? *)", want: true},
+		{expression: "cron(0 1 * *)", want: false},
+		{expression: "every day", want: false},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.expression, func(t *testing.T) {
+			require.Equal(t, tc.want, isValidScheduleExpression(tc.expression))
+		})
+	}
+}
@@ -1,35 +1,292 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	includeKey      = "include"
+	environmentsKey = "environments"
+	nameKey         = "name"
+)
+
+var (
+	// ErrIncludeCycle represents a configuration file that includes itself, directly or not.
+	ErrIncludeCycle = errors.New("include cycle")
+
+	// ErrUnknownEnvironment represents an environment that is not declared in the environments section.
+	ErrUnknownEnvironment = errors.New("unknown environment")
+)
+
 var (
 	osReadFile    = os.ReadFile
 	yamlUnmarshal = yaml.Unmarshal
 )
 
 type YAML struct {
-	fileName string
+	fileName    string
+	environment string
+	vars        map[string]string
+}
+
+// YAMLOption is a functional option to configure how the configuration file is loaded.
+type YAMLOption func(*YAML)
+
+// WithEnvironment sets the environment whose overlay is merged on top of the configuration.
+func WithEnvironment(environment string) YAMLOption {
+	return func(y *YAML) {
+		y.environment = environment
+	}
+}
+
+// WithVars sets variables that override the ones declared in the vars section.
+func WithVars(vars map[string]string) YAMLOption {
+	return func(y *YAML) {
+		if y.vars == nil {
+			y.vars = map[string]string{}
+		}
+
+		for k, v := range vars {
+			y.vars[k] = v
+		}
+	}
 }
 
-func NewYAML(fileName string) *YAML {
-	return &YAML{fileName: fileName}
+func NewYAML(fileName string, opts ...YAMLOption) *YAML {
+	y := &YAML{fileName: fileName}
+
+	for _, opt := range opts {
+		opt(y)
+	}
+
+	return y
 }
 
+// Parse loads the configuration file along with the files it includes, and merges the overlay of the environment on
+// top of it. Later files take precedence over earlier ones, and the including file takes precedence over its includes.
+// When variables are declared or set, their references in the string fields are replaced at the end.
 func (y *YAML) Parse() (*Config, error) {
-	yamlFile, err := osReadFile(y.fileName)
+	node, err := y.load(y.fileName, map[string]struct{}{}, nil)
 	if err != nil {
-		return nil, fmt.Errorf("read YAML file error: %w", err)
+		return nil, err
 	}
 
+	if y.environment != "" {
+		overlay := mappingValue(mappingValue(node, environmentsKey), y.environment)
+		if overlay == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEnvironment, y.environment)
+		}
+
+		node = mergeNodes(node, overlay)
+	}
+
+	node = withoutKeys(node, includeKey, environmentsKey)
+
 	var config Config
-	if err := yamlUnmarshal(yamlFile, &config); err != nil {
-		return nil, fmt.Errorf("unmarshal YAML file error: %w", err)
+	if node != nil {
+		if err := node.Decode(&config); err != nil {
+			return nil, fmt.Errorf("unmarshal YAML file error: %w", err)
+		}
+	}
+
+	if config.Vars != nil || y.vars != nil {
+		if config.Vars == nil {
+			config.Vars = map[string]string{}
+		}
+
+		for k, v := range y.vars {
+			config.Vars[k] = v
+		}
+
+		if err := interpolate(&config, config.Vars); err != nil {
+			return nil, err
+		}
 	}
 
 	return &config, nil
 }
+
+// load returns the root node of the file, merged with the files it includes. The paths of the includes are relative to
+// the directory of the file. When set, visit is called with the root node of every file, the including file first.
+func (y *YAML) load(
+	fileName string, visiting map[string]struct{}, visit func(fileName string, node *yaml.Node),
+) (*yaml.Node, error) {
+	absFileName, err := filepath.Abs(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if _, ok := visiting[absFileName]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrIncludeCycle, fileName)
+	}
+
+	visiting[absFileName] = struct{}{}
+	defer delete(visiting, absFileName)
+
+	yamlFile, err := osReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("read YAML file error: %w", err)
+	}
+
+	var document yaml.Node
+	if err := yamlUnmarshal(yamlFile, &document); err != nil {
+		return nil, fmt.Errorf("unmarshal YAML file error: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	node := document.Content[0]
+
+	if visit != nil {
+		visit(fileName, node)
+	}
+
+	var includes []string
+	if includeNode := mappingValue(node, includeKey); includeNode != nil {
+		if err := includeNode.Decode(&includes); err != nil {
+			return nil, fmt.Errorf("unmarshal YAML file error: %s: %w", fileName, err)
+		}
+	}
+
+	var merged *yaml.Node
+
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(fileName), include)
+		}
+
+		includeNode, err := y.load(include, visiting, visit)
+		if err != nil {
+			return nil, err
+		}
+
+		merged = mergeNodes(merged, includeNode)
+	}
+
+	return mergeNodes(merged, withoutKeys(node, includeKey)), nil
+}
+
+// mergeNodes deep merges the override into the base node. Mappings are merged key by key, and sequences whose items are
+// all mappings with a name are merged item by item, matching them by name. Any other value of the override replaces
+// the base one, except null values, which are ignored.
+func mergeNodes(base, override *yaml.Node) *yaml.Node {
+	base, override = resolveAlias(base), resolveAlias(override)
+
+	switch {
+	case override == nil || override.Tag == yamlNullTag:
+		return base
+	case base == nil:
+		return override
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		merged := *base
+		merged.Content = append([]*yaml.Node{}, base.Content...)
+
+		for i := 0; i+1 < len(override.Content); i += 2 {
+			key, value := override.Content[i], override.Content[i+1]
+
+			if j := mappingKeyIndex(&merged, key.Value); j >= 0 {
+				merged.Content[j+1] = mergeNodes(merged.Content[j+1], value)
+			} else {
+				merged.Content = append(merged.Content, key, value)
+			}
+		}
+
+		return &merged
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode &&
+		isKeyedByName(base) && isKeyedByName(override):
+		merged := *base
+		merged.Content = append([]*yaml.Node{}, base.Content...)
+
+		for _, item := range override.Content {
+			j := sequenceIndexByName(&merged, mappingValue(item, nameKey).Value)
+			if j >= 0 {
+				merged.Content[j] = mergeNodes(merged.Content[j], item)
+			} else {
+				merged.Content = append(merged.Content, item)
+			}
+		}
+
+		return &merged
+	default:
+		return override
+	}
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+func isKeyedByName(node *yaml.Node) bool {
+	for _, item := range node.Content {
+		name := mappingValue(resolveAlias(item), nameKey)
+		if name == nil || name.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+
+	if i := mappingKeyIndex(node, key); i >= 0 {
+		return node.Content[i+1]
+	}
+
+	return nil
+}
+
+func sequenceIndexByName(node *yaml.Node, name string) int {
+	for i, item := range node.Content {
+		if mappingValue(item, nameKey).Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// withoutKeys returns a copy of the mapping node without the given keys.
+func withoutKeys(node *yaml.Node, keys ...string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return node
+	}
+
+	result := *node
+	result.Content = make([]*yaml.Node, 0, len(node.Content))
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !slices.Contains(keys, node.Content[i].Value) {
+			result.Content = append(result.Content, node.Content[i], node.Content[i+1])
+		}
+	}
+
+	return &result
+}
@@ -65,6 +65,37 @@ func TestYAML_Parse(t *testing.T) {
 				Lambda:    DriagramLambda{Source: "git@", RoleName: "execute_lambda", Runtime: "go1.x"},
 			}},
 		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "DynamoDB",
+			fields: fields{fileName: testdataFolder + "/dynamodb.config.yaml"},
+			want: &Config{DynamoDBs: []DynamoDB{{
+				Name:          "orders",
+				BillingMode:   "PROVISIONED",
+				ReadCapacity:  10,
+				WriteCapacity: 5,
+				HashKey:       "customer_id",
+				RangeKey:      "order_id",
+				Attributes: []DynamoDBAttribute{
+					{Name: "customer_id", Type: "S"},
+					{Name: "order_id", Type: "S"},
+					{Name: "status", Type: "S"},
+				},
+				GlobalSecondaryIndexes: []DynamoDBIndex{{
+					Name:             "status-index",
+					HashKey:          "status",
+					ProjectionType:   "INCLUDE",
+					NonKeyAttributes: []string{"total"},
+				}},
+				TTLAttribute:        "expires_at",
+				PointInTimeRecovery: true,
+				StreamViewType:      "NEW_AND_OLD_IMAGES",
+				Files: []File{{
+					Name: "orders-dynamodb.tf",
+					Tmpl: `resource "aws_dynamodb_table" "{{ToSnake $.Name}}_dynamodb" {}`,
+				}},
+			}}},
+		},
 		{
 			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
 			name:   "Lambda",
@@ -125,6 +156,41 @@ func TestYAML_Parse(t *testing.T) {
 				}},
 			}}},
 		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "SNS topic",
+			fields: fields{fileName: testdataFolder + "/sns.config.topic.yaml"},
+			want: &Config{SNSs: []SNS{
+				{
+					Name:           "order-events",
+					BucketName:     "my-bucket",
+					BucketEvents:   []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+					KMSMasterKeyID: "var.sns_kms_key_id",
+					Subscriptions: []SNSSubscription{
+						{
+							Protocol:           "sqs",
+							Endpoint:           "target",
+							RawMessageDelivery: true,
+							FilterPolicy:       `{"event_type":["order_created"]}`,
+						},
+						{
+							Protocol:          "lambda",
+							Endpoint:          "exampleReceiver",
+							FilterPolicy:      `{"amount":[{"numeric":[">",100]}]}`,
+							FilterPolicyScope: "MessageBody",
+						},
+						{Protocol: "https", Endpoint: "https://example.com/hooks/orders"},
+						{Protocol: "email", Endpoint: "team@example.com"},
+					},
+				},
+				{
+					Name:          "order-events-fifo",
+					Mode:          SNSModeTopic,
+					FIFO:          true,
+					Subscriptions: []SNSSubscription{{Protocol: "sqs", Endpoint: "aws_sqs_queue.target_fifo_sqs.arn"}},
+				},
+			}},
+		},
 		{
 			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
 			name:   "SQS",
@@ -225,3 +291,134 @@ func TestYAML_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestYAML_Parse_Include(t *testing.T) {
+	stacks := Structure{Stacks: []Stack{{Name: "teststack"}}}
+
+	tests := []struct {
+		name        string
+		fileName    string
+		environment string
+		want        *Config
+		targetErr   error
+	}{
+		{
+			name:     "merge the included files by name",
+			fileName: testdataFolder + "/include.config.yaml",
+			want: &Config{
+				Structure: stacks,
+				SQSs: []SQS{
+					{Name: "target", MaxReceiveCount: 20, Files: []File{{Name: "target-sqs.tf"}}},
+					{Name: "source", MaxReceiveCount: 3},
+					{Name: "extra", MaxReceiveCount: 5},
+				},
+				Buckets: []S3{{Name: "reports", ExpirationDays: 30}},
+			},
+		},
+		{
+			name:        "environment overlay of the file",
+			fileName:    testdataFolder + "/include.config.yaml",
+			environment: "prd",
+			want: &Config{
+				Structure: stacks,
+				SQSs: []SQS{
+					{Name: "target", MaxReceiveCount: 50, Files: []File{{Name: "target-sqs.tf"}}},
+					{Name: "source", MaxReceiveCount: 3},
+					{Name: "extra", MaxReceiveCount: 5},
+				},
+				Buckets: []S3{{Name: "reports", ExpirationDays: 365}},
+			},
+		},
+		{
+			name:        "environment overlay of an included file",
+			fileName:    testdataFolder + "/include.config.yaml",
+			environment: "dev",
+			want: &Config{
+				Structure: stacks,
+				SQSs: []SQS{
+					{Name: "target", MaxReceiveCount: 20, Files: []File{{Name: "target-sqs.tf"}}},
+					{Name: "source", MaxReceiveCount: 3},
+					{Name: "extra", MaxReceiveCount: 5},
+				},
+				Buckets: []S3{{Name: "reports", ExpirationDays: 1}},
+			},
+		},
+		{
+			name:        "unknown environment",
+			fileName:    testdataFolder + "/include.config.yaml",
+			environment: "uat",
+			targetErr:   ErrUnknownEnvironment,
+		},
+		{
+			name:      "include cycle",
+			fileName:  testdataFolder + "/include.cycle.config.yaml",
+			targetErr: ErrIncludeCycle,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewYAML(tc.fileName, WithEnvironment(tc.environment)).Parse()
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestYAML_Parse_Vars(t *testing.T) {
+	t.Setenv("ATG_TEST_USER", "ci")
+
+	want := func(source string) *Config {
+		return &Config{
+			Vars:        map[string]string{"source": source, "domain": "example.com"},
+			APIGateways: []APIGateway{{StackName: "teststack", APIDomain: "api.example.com"}},
+			Lambdas: []Lambda{{
+				Name:        "exampleReceiver",
+				Source:      source,
+				Description: "Deployed by ci",
+				Envars:      map[string]string{"ENVIRONMENT": "${var.environment}"},
+				Files:       []File{{Name: "main.go", Tmpl: "${var.untouched}"}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		fileName  string
+		opts      []YAMLOption
+		want      *Config
+		targetErr error
+	}{
+		{
+			name:     "declared variables",
+			fileName: testdataFolder + "/vars.config.yaml",
+			want:     want("git@github.com:username/terraform-aws-lambda?ref=v1"),
+		},
+		{
+			name:     "variables overridden by the options",
+			fileName: testdataFolder + "/vars.config.yaml",
+			opts:     []YAMLOption{WithVars(map[string]string{"source": "git@github.com:username/other?ref=v2"})},
+			want:     want("git@github.com:username/other?ref=v2"),
+		},
+		{
+			name:      "undefined variable",
+			fileName:  testdataFolder + "/apigateway.config.yaml",
+			opts:      []YAMLOption{WithVars(map[string]string{"region": "us-east-1"})},
+			targetErr: ErrUndefinedVariable,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewYAML(tc.fileName, tc.opts...).Parse()
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
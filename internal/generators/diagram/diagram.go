@@ -2,8 +2,6 @@ package diagram
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 
@@ -11,6 +9,8 @@ import (
 
 	"github.com/diagram-code-generator/resources/pkg/transformers/drawiotoresources"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
@@ -21,14 +21,24 @@ type Diagram struct {
 	diagramFilename string
 	configFilename  string
 	output          string
+	fs              filesystem.FileSystem
+	yamlOptions     []config.YAMLOption
 }
 
-func NewDiagram(diagramFilename, configFilename, output string) *Diagram {
-	return &Diagram{diagramFilename: diagramFilename, configFilename: configFilename, output: output}
+func NewDiagram(diagramFilename, configFilename, output string, opts ...generators.Option) *Diagram {
+	options := generators.NewOptions(opts...)
+
+	return &Diagram{
+		diagramFilename: diagramFilename,
+		configFilename:  configFilename,
+		output:          output,
+		fs:              options.FileSystem,
+		yamlOptions:     options.YAMLOptions,
+	}
 }
 
 func (d *Diagram) Build() error {
-	yamlConfig, err := config.NewYAML(d.configFilename).Parse()
+	yamlConfig, err := config.NewYAML(d.configFilename, d.yamlOptions...).Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -50,10 +60,7 @@ func (d *Diagram) Build() error {
 		return fmt.Errorf("%w", err)
 	}
 
-	outputDir, _ := filepath.Split(d.output)
-	_ = os.MkdirAll(filepath.Base(outputDir), os.ModePerm)
-
-	err = os.WriteFile(d.output, data, os.ModePerm)
+	err = d.fs.WriteFile(d.output, data)
 	if err != nil {
 		return fmt.Errorf("%w", err)
 	}
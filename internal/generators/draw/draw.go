@@ -2,7 +2,6 @@ package draw
 
 import (
 	"fmt"
-	"os"
 	"path"
 
 	"gopkg.in/yaml.v3"
@@ -10,7 +9,9 @@ import (
 	"github.com/diagram-code-generator/resources/pkg/parser/graphviz/dot"
 	hcl "github.com/joselitofilho/hcl-parser-go/pkg/parser/hcl"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorerrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
@@ -20,18 +21,21 @@ import (
 
 // DefaultResourceImageMap defines the default resource images. Images from here: https://awsicons.dev/
 var DefaultResourceImageMap = config.Images{
-	awsresources.APIGatewayType: "assets/diagram/api_gateway.svg",
-	awsresources.CronType:       "assets/diagram/cron.svg",
-	awsresources.DatabaseType:   "assets/diagram/database_dynamo_db.svg",
-	awsresources.EndpointType:   "assets/diagram/endpoint.svg",
-	awsresources.GoogleBQType:   "assets/diagram/google_bigquery.svg",
-	awsresources.KinesisType:    "assets/diagram/kinesis_data_stream.svg",
-	awsresources.LambdaType:     "assets/diagram/lambda.svg",
-	awsresources.RestfulAPIType: "assets/diagram/restful_api.svg",
-	awsresources.S3Type:         "assets/diagram/s3_bucket.svg",
-	awsresources.SNSType:        "assets/diagram/sns.svg",
-	awsresources.SQSType:        "assets/diagram/sqs.svg",
-	awsresources.UnknownType:    "",
+	awsresources.APIGatewayType:   "assets/diagram/api_gateway.svg",
+	awsresources.CronType:         "assets/diagram/cron.svg",
+	awsresources.DatabaseType:     "assets/diagram/database_dynamo_db.svg",
+	awsresources.DynamoDBType:     "assets/diagram/database_dynamo_db.svg",
+	awsresources.EndpointType:     "assets/diagram/endpoint.svg",
+	awsresources.EventBridgeType:  "assets/diagram/eventbridge.svg",
+	awsresources.GoogleBQType:     "assets/diagram/google_bigquery.svg",
+	awsresources.KinesisType:      "assets/diagram/kinesis_data_stream.svg",
+	awsresources.LambdaType:       "assets/diagram/lambda.svg",
+	awsresources.RestfulAPIType:   "assets/diagram/restful_api.svg",
+	awsresources.S3Type:           "assets/diagram/s3_bucket.svg",
+	awsresources.SNSType:          "assets/diagram/sns.svg",
+	awsresources.SQSType:          "assets/diagram/sqs.svg",
+	awsresources.WebSocketAPIType: "assets/diagram/api_gateway.svg",
+	awsresources.UnknownType:      "",
 }
 
 type Draw struct {
@@ -39,14 +43,25 @@ type Draw struct {
 	files          []string
 	configFilename string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewDraw(workdirs, files []string, configFilename, output string) *Draw {
-	return &Draw{workdirs: workdirs, files: files, configFilename: configFilename, output: output}
+func NewDraw(workdirs, files []string, configFilename, output string, opts ...generators.Option) *Draw {
+	options := generators.NewOptions(opts...)
+
+	return &Draw{
+		workdirs:       workdirs,
+		files:          files,
+		configFilename: configFilename,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (d *Draw) Build() error {
-	yamlParser := config.NewYAML(d.configFilename)
+	yamlParser := config.NewYAML(d.configFilename, d.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -60,8 +75,6 @@ func (d *Draw) Build() error {
 
 	resc := terraformtoresources.NewTransformer(yamlConfig, tfConfig).Transform()
 
-	_ = os.Mkdir(d.output, os.ModePerm)
-
 	diagramConfig, err := resourcestoyaml.NewTransformer(yamlConfig, resc).Transform()
 	if err != nil {
 		return fmt.Errorf("%w", err)
@@ -79,13 +92,7 @@ func (d *Draw) Build() error {
 		return fmt.Errorf("%w", err)
 	}
 
-	yamlfile, err := os.Create(path.Join(d.output, yamlFilename))
-	if err != nil {
-		return fmt.Errorf("%w", err)
-	}
-	defer yamlfile.Close()
-
-	if _, err = yamlfile.WriteString(string(yamlData)); err != nil {
+	if err := d.fs.WriteFile(path.Join(d.output, yamlFilename), yamlData); err != nil {
 		return fmt.Errorf("%w", err)
 	}
 
@@ -113,13 +120,7 @@ func (d *Draw) Build() error {
 
 	dotFilename += ".dot"
 
-	dotfile, err := os.Create(path.Join(d.output, dotFilename))
-	if err != nil {
-		return fmt.Errorf("%w", err)
-	}
-	defer dotfile.Close()
-
-	if _, err := dotfile.WriteString(dotContent); err != nil {
+	if err := d.fs.WriteFile(path.Join(d.output, dotFilename), []byte(dotContent)); err != nil {
 		return fmt.Errorf("%w", err)
 	}
 
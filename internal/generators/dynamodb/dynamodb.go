@@ -0,0 +1,234 @@
+package dynamodb
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+const (
+	billingModePayPerRequest = "PAY_PER_REQUEST"
+	billingModeProvisioned   = "PROVISIONED"
+
+	defaultAttributeType  = "S"
+	defaultProjectionType = "ALL"
+	defaultCapacity       = 5
+)
+
+type AttributeData struct {
+	Name string
+	Type string
+}
+
+type IndexData struct {
+	Name             string
+	HashKey          string
+	RangeKey         string
+	ProjectionType   string
+	NonKeyAttributes string
+	ReadCapacity     int
+	WriteCapacity    int
+}
+
+type Data struct {
+	Name                   string
+	BillingMode            string
+	Provisioned            bool
+	ReadCapacity           int
+	WriteCapacity          int
+	HashKey                string
+	RangeKey               string
+	Attributes             []AttributeData
+	GlobalSecondaryIndexes []IndexData
+	TTLAttribute           string
+	PointInTimeRecovery    bool
+	StreamViewType         string
+	Tags                   map[string]string
+}
+
+type DynamoDB struct {
+	configFileName string
+	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
+}
+
+func NewDynamoDB(configFileName, output string, opts ...generators.Option) *DynamoDB {
+	options := generators.NewOptions(opts...)
+
+	return &DynamoDB{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
+}
+
+func (d *DynamoDB) Build() error {
+	yamlParser := config.NewYAML(d.configFileName, d.yamlOptions...)
+
+	yamlConfig, err := yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(d.output, "mod")
+
+	result := make([]string, 0, len(yamlConfig.DynamoDBs))
+
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.DynamoDB))
+
+	tg := generators.NewGenerator()
+
+	var errs []error
+
+	for i := range yamlConfig.DynamoDBs {
+		conf := yamlConfig.DynamoDBs[i]
+
+		data := buildData(&conf)
+		data.Tags = yamlConfig.ResourceTags(conf.Tags)
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			if err := generators.GenerateFiles(tg, d.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			fmtcolor.White.Printf("DynamoDB '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "dynamodb-tf-template", templates[filenameDynamoDBtf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameDynamoDBtf)
+
+		err := generators.GenerateFile(tg, d.fs, nil, filenameDynamoDBtf, strings.Join(result, "\n"), outputFile, Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		fmtcolor.White.Println("DynamoDB has been generated successfully")
+	}
+
+	return errors.Join(errs...)
+}
+
+func buildData(conf *config.DynamoDB) Data {
+	billingMode := strings.ToUpper(conf.BillingMode)
+	if billingMode == "" {
+		billingMode = billingModePayPerRequest
+	}
+
+	provisioned := billingMode == billingModeProvisioned
+
+	return Data{
+		Name:                   conf.Name,
+		BillingMode:            billingMode,
+		Provisioned:            provisioned,
+		ReadCapacity:           capacityOrDefault(conf.ReadCapacity),
+		WriteCapacity:          capacityOrDefault(conf.WriteCapacity),
+		HashKey:                conf.HashKey,
+		RangeKey:               conf.RangeKey,
+		Attributes:             buildAttributes(conf),
+		GlobalSecondaryIndexes: buildIndexes(conf),
+		TTLAttribute:           conf.TTLAttribute,
+		PointInTimeRecovery:    conf.PointInTimeRecovery,
+		StreamViewType:         conf.StreamViewType,
+	}
+}
+
+// buildAttributes returns the declared attributes plus any key attribute that was not declared. DynamoDB requires
+// every key used by the table or by its indexes to be declared, so missing ones default to the string type.
+func buildAttributes(conf *config.DynamoDB) []AttributeData {
+	attributes := make([]AttributeData, 0, len(conf.Attributes))
+	declared := map[string]struct{}{}
+
+	addAttribute := func(name, attrType string) {
+		if name == "" {
+			return
+		}
+
+		if _, ok := declared[name]; ok {
+			return
+		}
+
+		if attrType == "" {
+			attrType = defaultAttributeType
+		}
+
+		declared[name] = struct{}{}
+
+		attributes = append(attributes, AttributeData{Name: name, Type: strings.ToUpper(attrType)})
+	}
+
+	for _, attr := range conf.Attributes {
+		addAttribute(attr.Name, attr.Type)
+	}
+
+	addAttribute(conf.HashKey, "")
+	addAttribute(conf.RangeKey, "")
+
+	for _, index := range conf.GlobalSecondaryIndexes {
+		addAttribute(index.HashKey, "")
+		addAttribute(index.RangeKey, "")
+	}
+
+	return attributes
+}
+
+func buildIndexes(conf *config.DynamoDB) []IndexData {
+	indexes := make([]IndexData, 0, len(conf.GlobalSecondaryIndexes))
+
+	for _, index := range conf.GlobalSecondaryIndexes {
+		projectionType := strings.ToUpper(index.ProjectionType)
+		if projectionType == "" {
+			projectionType = defaultProjectionType
+		}
+
+		nonKeyAttributes := make([]string, 0, len(index.NonKeyAttributes))
+		for _, attr := range index.NonKeyAttributes {
+			nonKeyAttributes = append(nonKeyAttributes, fmt.Sprintf("%q", attr))
+		}
+
+		indexes = append(indexes, IndexData{
+			Name:             index.Name,
+			HashKey:          index.HashKey,
+			RangeKey:         index.RangeKey,
+			ProjectionType:   projectionType,
+			NonKeyAttributes: strings.Join(nonKeyAttributes, ", "),
+			ReadCapacity:     capacityOrDefault(index.ReadCapacity),
+			WriteCapacity:    capacityOrDefault(index.WriteCapacity),
+		})
+	}
+
+	return indexes
+}
+
+func capacityOrDefault(capacity int) int {
+	if capacity <= 0 {
+		return defaultCapacity
+	}
+
+	return capacity
+}
@@ -0,0 +1,14 @@
+package dynamodb
+
+import (
+	_ "embed"
+)
+
+const filenameDynamoDBtf = "dynamodb.tf"
+
+//go:embed tmpls/dynamodb.tf.tmpl
+var tmplDynamoDBtf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameDynamoDBtf: string(tmplDynamoDBtf),
+}
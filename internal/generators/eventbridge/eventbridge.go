@@ -0,0 +1,360 @@
+package eventbridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Name       string
+	Label      string
+	DefaultBus bool
+	Rules      []RuleData
+	Tags       map[string]string
+}
+
+type RuleData struct {
+	Name           string
+	Label          string
+	Description    string
+	EventPattern   string
+	Targets        []TargetData
+	RoleStatements []RoleStatementData
+}
+
+type TargetData struct {
+	Label                    string
+	Type                     string
+	ARN                      string
+	RoleARN                  bool
+	MessageGroupID           string
+	InputPaths               map[string]string
+	InputTemplate            string
+	MaximumEventAgeInSeconds int
+	MaximumRetryAttempts     int
+	DeadLetterARN            string
+}
+
+type RoleStatementData struct {
+	Action   string
+	Resource string
+}
+
+type QueuePolicyData struct {
+	Label      string
+	SourceARNs []string
+}
+
+// targetResource describes how the targets of a type refer to the resources of the configuration.
+type targetResource struct {
+	suffix  string
+	tfLabel string
+}
+
+var targetResources = map[string]targetResource{
+	config.EventBridgeTargetLambda:  {"_lambda", awsresources.LabelAWSLambdaFunction},
+	config.EventBridgeTargetSQS:     {"_sqs", awsresources.LabelAWSSQSQueue},
+	config.EventBridgeTargetKinesis: {"_kinesis", awsresources.LabelAWSKinesisStream},
+	config.EventBridgeTargetSNS:     {"_sns", awsresources.LabelAWSSNSTopic},
+}
+
+// Actions allowed to the role assumed by EventBridge to deliver the events to the targets that require one.
+var roleActions = map[string]string{
+	config.EventBridgeTargetKinesis:       "kinesis:PutRecord",
+	config.EventBridgeTargetStepFunctions: "states:StartExecution",
+}
+
+type EventBridge struct {
+	configFileName string
+	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
+}
+
+func NewEventBridge(configFileName, output string, opts ...generators.Option) *EventBridge {
+	options := generators.NewOptions(opts...)
+
+	return &EventBridge{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
+}
+
+func (e *EventBridge) Build() error {
+	yamlParser := config.NewYAML(e.configFileName, e.yamlOptions...)
+
+	yamlConfig, err := yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(e.output, "mod")
+
+	result := make([]string, 0, len(yamlConfig.EventBridges))
+
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.EventBridge))
+
+	tg := generators.NewGenerator()
+
+	var errs []error
+
+	queuePolicies := newQueuePolicies()
+
+	for i := range yamlConfig.EventBridges {
+		conf := yamlConfig.EventBridges[i]
+
+		data := buildData(&conf, yamlConfig.ResourceTags(conf.Tags))
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			if err := generators.GenerateFiles(tg, e.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			fmtcolor.White.Printf("EventBridge '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		queuePolicies.add(&conf, &data)
+
+		output, err := tg.Build(data, "eventbridge-tf-template", templates[filenameEventBridgetf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, strings.TrimSpace(output))
+	}
+
+	for _, policy := range queuePolicies.list() {
+		output, err := tg.Build(policy, "eventbridge-queue-policy-tf-template",
+			templates[filenameEventBridgeQueuePolicytf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, strings.TrimSpace(output))
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameEventBridgetf)
+
+		err := generators.GenerateFile(tg, e.fs, nil, filenameEventBridgetf, strings.Join(result, "\n\n")+"\n", outputFile,
+			Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		fmtcolor.White.Println("EventBridge has been generated successfully")
+	}
+
+	return errors.Join(errs...)
+}
+
+// BusLabel returns the Terraform label of the event bus.
+func BusLabel(busName string) string {
+	return strcase.ToSnake(busName) + "_event_bus"
+}
+
+// RuleLabel returns the Terraform label of a rule of the event bus.
+func RuleLabel(busName, ruleName string) string {
+	return fmt.Sprintf("%s_%s_rule", strcase.ToSnake(busName), strcase.ToSnake(ruleName))
+}
+
+// TargetLabel returns the Terraform label of the resource receiving the events, and its ARN as a Terraform expression.
+// The label is empty when the target is a literal ARN, or a Terraform expression that is not a resource reference.
+func TargetLabel(target *config.EventBridgeTarget) (label, arn string) {
+	return resourceLabelAndARN(target.Type, target.Target)
+}
+
+func resourceLabelAndARN(targetType, target string) (label, arn string) {
+	res, ok := targetResources[targetType]
+
+	switch {
+	case !config.IsReference(target) && ok:
+		label = strcase.ToSnake(target) + res.suffix
+
+		return label, fmt.Sprintf("%s.%s.arn", res.tfLabel, label)
+	case strings.HasPrefix(target, "arn:"):
+		return "", generators.QuoteIfLiteral(target)
+	}
+
+	resourceARN := awsresources.ParseResourceARN(target, awsresources.UnknownType)
+	if ok && resourceARN.Type != res.tfLabel {
+		resourceARN.Label = ""
+	}
+
+	return resourceARN.Label, generators.QuoteIfLiteral(target)
+}
+
+func buildData(conf *config.EventBridge, tags map[string]string) Data {
+	busLabel := BusLabel(conf.Name)
+
+	rules := make([]RuleData, 0, len(conf.Rules))
+
+	for _, rule := range conf.Rules {
+		ruleLabel := RuleLabel(conf.Name, rule.Name)
+
+		rules = append(rules, RuleData{
+			Name:           rule.Name,
+			Label:          ruleLabel,
+			Description:    rule.Description,
+			EventPattern:   quoteJSON(rule.EventPattern),
+			Targets:        buildTargets(ruleLabel, rule.Targets),
+			RoleStatements: buildRoleStatements(rule.Targets),
+		})
+	}
+
+	return Data{
+		Name:       conf.Name,
+		Label:      busLabel,
+		DefaultBus: conf.IsDefaultBus(),
+		Rules:      rules,
+		Tags:       tags,
+	}
+}
+
+func buildTargets(ruleLabel string, targets []config.EventBridgeTarget) []TargetData {
+	result := make([]TargetData, 0, len(targets))
+	countByType := map[string]int{}
+
+	for i := range targets {
+		target := targets[i]
+
+		label, arn := TargetLabel(&target)
+		if label == "" {
+			countByType[target.Type]++
+			label = fmt.Sprintf("%s_%d", strcase.ToSnake(target.Type), countByType[target.Type])
+		}
+
+		var deadLetterARN string
+		if target.DeadLetterQueue != "" {
+			_, deadLetterARN = resourceLabelAndARN(config.EventBridgeTargetSQS, target.DeadLetterQueue)
+		}
+
+		var inputTemplate string
+		if target.InputTransformer.InputTemplate != "" {
+			inputTemplate = fmt.Sprintf("%q", target.InputTransformer.InputTemplate)
+		}
+
+		_, needsRole := roleActions[target.Type]
+
+		result = append(result, TargetData{
+			Label:                    fmt.Sprintf("%s_to_%s", ruleLabel, label),
+			Type:                     target.Type,
+			ARN:                      arn,
+			RoleARN:                  needsRole,
+			MessageGroupID:           target.MessageGroupID,
+			InputPaths:               target.InputTransformer.InputPaths,
+			InputTemplate:            inputTemplate,
+			MaximumEventAgeInSeconds: target.RetryPolicy.MaximumEventAgeInSeconds,
+			MaximumRetryAttempts:     target.RetryPolicy.MaximumRetryAttempts,
+			DeadLetterARN:            deadLetterARN,
+		})
+	}
+
+	return result
+}
+
+func buildRoleStatements(targets []config.EventBridgeTarget) []RoleStatementData {
+	var statements []RoleStatementData
+
+	for i := range targets {
+		action, ok := roleActions[targets[i].Type]
+		if !ok {
+			continue
+		}
+
+		_, arn := TargetLabel(&targets[i])
+
+		statements = append(statements, RoleStatementData{Action: action, Resource: arn})
+	}
+
+	return statements
+}
+
+// quoteJSON returns the event pattern as a Terraform string, compacting it when it is a valid JSON document.
+func quoteJSON(value string) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, []byte(value)); err == nil {
+		value = compacted.String()
+	}
+
+	return fmt.Sprintf("%q", strings.TrimSpace(value))
+}
+
+// queuePolicies gathers the rules sending events to each queue, as targets or as dead-letter queues, so that a single
+// policy is generated per queue.
+type queuePolicies struct {
+	labels     []string
+	sourceARNs map[string][]string
+}
+
+func newQueuePolicies() *queuePolicies {
+	return &queuePolicies{sourceARNs: map[string][]string{}}
+}
+
+func (q *queuePolicies) add(conf *config.EventBridge, data *Data) {
+	for i, rule := range conf.Rules {
+		ruleARN := fmt.Sprintf("%s.%s.arn", awsresources.LabelAWSCron, data.Rules[i].Label)
+
+		for _, target := range rule.Targets {
+			if target.Type == config.EventBridgeTargetSQS {
+				label, _ := TargetLabel(&target)
+				q.addSource(label, ruleARN)
+			}
+
+			if target.DeadLetterQueue != "" {
+				label, _ := resourceLabelAndARN(config.EventBridgeTargetSQS, target.DeadLetterQueue)
+				q.addSource(label, ruleARN)
+			}
+		}
+	}
+}
+
+func (q *queuePolicies) addSource(label, ruleARN string) {
+	if label == "" {
+		return
+	}
+
+	if _, ok := q.sourceARNs[label]; !ok {
+		q.labels = append(q.labels, label)
+	}
+
+	for _, arn := range q.sourceARNs[label] {
+		if arn == ruleARN {
+			return
+		}
+	}
+
+	q.sourceARNs[label] = append(q.sourceARNs[label], ruleARN)
+}
+
+func (q *queuePolicies) list() []QueuePolicyData {
+	policies := make([]QueuePolicyData, 0, len(q.labels))
+	for _, label := range q.labels {
+		policies = append(policies, QueuePolicyData{Label: label, SourceARNs: q.sourceARNs[label]})
+	}
+
+	return policies
+}
@@ -0,0 +1,118 @@
+package eventbridge
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestEventBridge_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "buses with rules and targets",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "eventbridge.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				eventBridgeTf := path.Join(output, "mod", "eventbridge.tf")
+				require.FileExists(tb, eventBridgeTf)
+
+				eventBridgeTfData, err := os.ReadFile(eventBridgeTf)
+				require.NoError(tb, err)
+
+				content := string(eventBridgeTfData)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_bus" "orders_event_bus"`)
+				require.NotContains(tb, content, `resource "aws_cloudwatch_event_bus" "default_event_bus"`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "orders_order_created_rule"`)
+				require.Contains(tb, content,
+					`event_pattern  = "{\"source\":[\"checkout\"],\"detail-type\":[\"OrderCreated\"]}"`)
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_event_target" "orders_order_created_rule_to_process_order_lambda"`)
+				require.Contains(tb, content,
+					`resource "aws_lambda_permission" "orders_order_created_rule_to_process_order_lambda_permission"`)
+				require.Contains(tb, content, `maximum_retry_attempts       = 3`)
+				require.Contains(tb, content, `arn = aws_sqs_queue.orders_dlq_sqs.arn`)
+				require.Contains(tb, content, `message_group_id = "orders"`)
+				require.Contains(tb, content, `input_template = "{\"orderId\": <orderId>}"`)
+				require.Contains(tb, content, `role_arn       = aws_iam_role.orders_order_created_rule_role.arn`)
+				require.Contains(tb, content, `Action   = ["states:StartExecution"]`)
+				require.Contains(tb, content, `arn  = "arn:aws:lambda:us-east-1:123456789012:function:audit"`)
+				require.Contains(tb, content, `resource "aws_sqs_queue_policy" "orders_sqs_from_eventbridge_policy"`)
+				require.Contains(tb, content, `aws_cloudwatch_event_rule.orders_order_shipped_rule.arn,`)
+			},
+		},
+		{
+			name: "override default template and customise a bus",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "eventbridge.config.custom.yaml"),
+				output:         path.Join(testOutput, "custom"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "payments-eventbridge.tf"))
+
+				eventBridgeTfData, err := os.ReadFile(path.Join(modPath, "eventbridge.tf"))
+				require.NoError(tb, err)
+
+				content := string(eventBridgeTfData)
+				require.Contains(tb, content, `resource "aws_sqs_queue_policy" "orders_sqs_from_eventbridge_policy" {}`)
+				require.NotContains(tb, content, `payments`)
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewEventBridge(tc.fields.configFileName, tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package eventbridge
+
+import (
+	_ "embed"
+)
+
+const (
+	filenameEventBridgetf            = "eventbridge.tf"
+	filenameEventBridgeQueuePolicytf = "eventbridge-queue-policy.tf"
+)
+
+var (
+	//go:embed tmpls/eventbridge.tf.tmpl
+	tmplEventBridgetf []byte
+
+	//go:embed tmpls/eventbridge-queue-policy.tf.tmpl
+	tmplEventBridgeQueuePolicytf []byte
+)
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameEventBridgetf:            string(tmplEventBridgetf),
+	filenameEventBridgeQueuePolicytf: string(tmplEventBridgeQueuePolicytf),
+}
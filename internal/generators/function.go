@@ -0,0 +1,75 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// FunctionData represents the settings of a Lambda function, rendered by the lambda and apigateway templates. The
+// layers, the dead-letter target and the VPC ids are Terraform expressions, so literal ARNs and ids are quoted.
+type FunctionData struct {
+	MemorySize                   int
+	Timeout                      int
+	Architectures                string
+	EphemeralStorage             int
+	ReservedConcurrentExecutions int
+	Layers                       string
+	DeadLetterTargetARN          string
+	TracingMode                  string
+	VPC                          bool
+	SubnetIDs                    string
+	SecurityGroupIDs             string
+	LogRetentionInDays           int
+}
+
+// NewFunctionData returns the template data of the settings of a Lambda function.
+func NewFunctionData(conf *config.LambdaFunction) FunctionData {
+	data := FunctionData{
+		MemorySize:                   conf.MemorySize,
+		Timeout:                      conf.Timeout,
+		Architectures:                QuoteList(conf.Architectures, true),
+		EphemeralStorage:             conf.EphemeralStorage,
+		ReservedConcurrentExecutions: conf.ReservedConcurrentExecutions,
+		Layers:                       QuoteList(conf.Layers, false),
+		TracingMode:                  conf.TracingMode,
+		VPC:                          len(conf.VPC.SubnetIDs) > 0 || len(conf.VPC.SecurityGroupIDs) > 0,
+		SubnetIDs:                    QuoteList(conf.VPC.SubnetIDs, false),
+		SecurityGroupIDs:             QuoteList(conf.VPC.SecurityGroupIDs, false),
+		LogRetentionInDays:           conf.LogRetentionInDays,
+	}
+
+	if conf.DeadLetterTargetARN != "" {
+		data.DeadLetterTargetARN = QuoteIfLiteral(conf.DeadLetterTargetARN)
+	}
+
+	return data
+}
+
+// QuoteIfLiteral returns the value as a Terraform expression: references to variables, locals, modules, data sources
+// and resources are kept as they are, and any other value is quoted.
+func QuoteIfLiteral(value string) string {
+	for _, prefix := range []string{"var.", "local.", "module.", "data.", "aws_"} {
+		if strings.HasPrefix(value, prefix) {
+			return value
+		}
+	}
+
+	return fmt.Sprintf("%q", value)
+}
+
+// QuoteList returns the values as the items of a Terraform list, quoting all of them or only the literal ones.
+func QuoteList(values []string, quoteAll bool) string {
+	items := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if quoteAll {
+			items = append(items, fmt.Sprintf("%q", value))
+		} else {
+			items = append(items, QuoteIfLiteral(value))
+		}
+	}
+
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,88 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+func TestNewFunctionData(t *testing.T) {
+	tests := []struct {
+		name string
+		conf config.LambdaFunction
+		want FunctionData
+	}{
+		{
+			name: "all settings",
+			conf: config.LambdaFunction{
+				MemorySize:                   512,
+				Timeout:                      30,
+				Architectures:                []string{config.LambdaArchitectureARM},
+				EphemeralStorage:             1024,
+				ReservedConcurrentExecutions: 5,
+				Layers:                       []string{"arn:aws:lambda:us-east-1:123456789012:layer:shared:1", "var.layer"},
+				DeadLetterTargetARN:          "aws_sqs_queue.failed_sqs.arn",
+				TracingMode:                  config.LambdaTracingModeActive,
+				VPC: config.LambdaVPC{
+					SubnetIDs:        []string{"subnet-1", "subnet-2"},
+					SecurityGroupIDs: []string{"aws_security_group.lambda.id"},
+				},
+				LogRetentionInDays: 14,
+			},
+			want: FunctionData{
+				MemorySize:                   512,
+				Timeout:                      30,
+				Architectures:                `"arm64"`,
+				EphemeralStorage:             1024,
+				ReservedConcurrentExecutions: 5,
+				Layers:                       `"arn:aws:lambda:us-east-1:123456789012:layer:shared:1", var.layer`,
+				DeadLetterTargetARN:          "aws_sqs_queue.failed_sqs.arn",
+				TracingMode:                  config.LambdaTracingModeActive,
+				VPC:                          true,
+				SubnetIDs:                    `"subnet-1", "subnet-2"`,
+				SecurityGroupIDs:             "aws_security_group.lambda.id",
+				LogRetentionInDays:           14,
+			},
+		},
+		{
+			name: "literal dead-letter target",
+			conf: config.LambdaFunction{DeadLetterTargetARN: "arn:aws:sns:us-east-1:123456789012:failed"},
+			want: FunctionData{DeadLetterTargetARN: `"arn:aws:sns:us-east-1:123456789012:failed"`},
+		},
+		{
+			name: "no settings",
+			want: FunctionData{},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, NewFunctionData(&tc.conf))
+		})
+	}
+}
+
+func TestQuoteIfLiteral(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "variable", value: "var.subnet_id", want: "var.subnet_id"},
+		{name: "resource", value: "aws_sqs_queue.my_sqs.arn", want: "aws_sqs_queue.my_sqs.arn"},
+		{name: "data source", value: "data.aws_subnet.private.id", want: "data.aws_subnet.private.id"},
+		{name: "literal", value: "subnet-0123456789abcdef0", want: `"subnet-0123456789abcdef0"`},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, QuoteIfLiteral(tc.value))
+		})
+	}
+}
@@ -1,13 +1,37 @@
 package generators
 
 import (
+	"errors"
+	"fmt"
+	"go/format"
+	"path"
+	"slices"
+	"sort"
 	"strings"
 	"text/template"
 
 	templategenerators "github.com/diagram-code-generator/template/pkg/generators"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 
-	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+// Suffixes removed from the environment variables to name the dependencies.
+var dependencyNameSuffixes = []string{"_URL", "_NAME", "_ARN"}
+
+// Kinds of the dependencies by the type of the resource their environment variable refers to.
+var dependencyKinds = map[awsresources.ResourceType]string{
+	awsresources.SQSType:     DependencySQS,
+	awsresources.S3Type:      DependencyS3,
+	awsresources.KinesisType: DependencyKinesis,
+}
+
+// Escapes of the Terraform quoted strings, including the interpolation and directive sequences so the values are kept
+// as they are written.
+var hclStringEscaper = strings.NewReplacer(
+	`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{",
 )
 
 // NewGenerator initialises a new instance of templategenerators.TemplateGenerator with additional template functions
@@ -17,34 +41,99 @@ func NewGenerator() *templategenerators.TemplateGenerator {
 		templategenerators.WithExtraFuncs(template.FuncMap{
 			"getFileByName":  func(files map[string]File, name string) File { return files[name] },
 			"getFileImports": func(files map[string]File, name string) []string { return files[name].Imports },
+			"hasDependency": func(dependencies []Dependency, kind string) bool {
+				return slices.ContainsFunc(dependencies, func(d Dependency) bool { return d.Kind == kind })
+			},
+			"quoteHCL": QuoteHCL,
 		}),
 	)
 }
 
-// MustGenerateFile generates a single file using the provided template. It logs any errors encountered during the
-// generation process, except for the ErrUnsupportedFileType error, which is ignored.
-func MustGenerateFile(tg *templategenerators.TemplateGenerator,
+// QuoteHCL returns the string as a Terraform quoted string literal.
+func QuoteHCL(s string) string {
+	return `"` + hclStringEscaper.Replace(s) + `"`
+}
+
+// GenerateFile generates a single file using the provided template and writes it through the file system. It returns
+// any error encountered during the generation process, including a file skipped to keep its hand edits.
+func GenerateFile(tg *templategenerators.TemplateGenerator, fs filesystem.FileSystem,
 	templatesMap map[string]string, fileName, fileTmpl, outputFile string, data any,
-) {
-	err := tg.GenerateFile(templatesMap, fileName, fileTmpl, outputFile, data)
-	if err != nil {
-		fmtcolor.Yellow.Println(err)
+) error {
+	if fileTmpl == "" {
+		fileTmpl = templatesMap[fileName]
 	}
+
+	return generateFile(tg, fs, fileName, fileTmpl, outputFile, data)
 }
 
-// MustGenerateFiles generates multiple files at once using the provided templates. It logs any errors encountered
-// during the generation process.
-func MustGenerateFiles(tg *templategenerators.TemplateGenerator,
+// GenerateFiles generates multiple files at once using the provided templates and writes them through the file system.
+// A file that fails does not stop the others: all errors are joined and returned.
+func GenerateFiles(tg *templategenerators.TemplateGenerator, fs filesystem.FileSystem,
 	defaultTemplatesMap map[string]string, filesMap map[string]File, data any, output string,
-) {
+) error {
 	templatesMap := map[string]string{}
+	for k, tmpl := range defaultTemplatesMap {
+		templatesMap[k] = tmpl
+	}
+
 	for k, file := range filesMap {
 		templatesMap[k] = file.Tmpl
 	}
 
-	err := tg.GenerateFiles(defaultTemplatesMap, templatesMap, data, output)
+	fileNames := make([]string, 0, len(templatesMap))
+	for fileName := range templatesMap {
+		fileNames = append(fileNames, fileName)
+	}
+
+	sort.Strings(fileNames)
+
+	var errs []error
+
+	for _, fileName := range fileNames {
+		errs = append(errs, generateFile(tg, fs, fileName, templatesMap[fileName], path.Join(output, fileName), data))
+	}
+
+	return errors.Join(errs...)
+}
+
+// generateFile renders the template in memory, formats the content based on the file extension and writes it. When
+// the formatting fails, the unformatted content is still written.
+func generateFile(tg *templategenerators.TemplateGenerator, fs filesystem.FileSystem,
+	fileName, fileTmpl, outputFile string, data any,
+) error {
+	tmplName := fmt.Sprintf("%s-template", strings.ReplaceAll(fileName, ".", "-"))
+
+	content, err := tg.Build(data, tmplName, fileTmpl)
 	if err != nil {
-		fmtcolor.Yellow.Println(err)
+		return fmt.Errorf("%s: %w", outputFile, err)
+	}
+
+	formatted, formatErr := formatByExt(fileName, []byte(content))
+
+	if err := fs.WriteFile(outputFile, formatted); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if formatErr != nil {
+		return fmt.Errorf("%s: %w", outputFile, formatErr)
+	}
+
+	return nil
+}
+
+func formatByExt(fileName string, content []byte) ([]byte, error) {
+	switch path.Ext(fileName) {
+	case ".go":
+		formatted, err := format.Source(content)
+		if err != nil {
+			return content, fmt.Errorf("error formatting source code: %w", err)
+		}
+
+		return formatted, nil
+	case ".tf":
+		return hclwrite.Format(content), nil
+	default:
+		return content, nil
 	}
 }
 
@@ -63,6 +152,43 @@ func CreateFilesMap(files []config.File) map[string]File {
 	return filesConf
 }
 
+// CreateDependencies returns the downstream resources implied by the environment variables of a Lambda, sorted by
+// environment variable. The kind of each resource comes from the Terraform resource the value refers to or, failing
+// that, from the name of the variable. The name of a dependency is the variable without its URL, NAME or ARN suffix.
+func CreateDependencies(envars map[string]string) []Dependency {
+	keys := make([]string, 0, len(envars))
+	for key := range envars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	dependencies := []Dependency{}
+	names := map[string]struct{}{}
+
+	for _, key := range keys {
+		kind, ok := dependencyKinds[awsresources.EnvarResourceType(key, envars[key])]
+		if !ok {
+			continue
+		}
+
+		name := key
+		for _, suffix := range dependencyNameSuffixes {
+			name = strings.TrimSuffix(name, suffix)
+		}
+
+		if _, ok := names[name]; ok {
+			continue
+		}
+
+		names[name] = struct{}{}
+
+		dependencies = append(dependencies, Dependency{Name: name, Envar: key, Kind: kind})
+	}
+
+	return dependencies
+}
+
 // CreateTemplatesMap creates a map of templates from a slice of config.FilenameTemplateMap structs. Each struct
 // represents a map where keys are file names and values are corresponding templates. It merges these maps into a
 // single map.
@@ -7,11 +7,12 @@ import (
 	"testing"
 
 	templategenerators "github.com/diagram-code-generator/template/pkg/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	"github.com/stretchr/testify/require"
 )
 
-func TestMustGenerateFile(t *testing.T) {
+func TestGenerateFile(t *testing.T) {
 	type args struct {
 		tg           *templategenerators.TemplateGenerator
 		templatesMap map[string]string
@@ -28,6 +29,7 @@ func TestMustGenerateFile(t *testing.T) {
 		name             string
 		args             args
 		extraValidations func(testing.TB, string)
+		wantErr          bool
 	}{
 		{
 			name: "successful go file generation and formatting",
@@ -45,13 +47,13 @@ func TestMustGenerateFile(t *testing.T) {
 			},
 		},
 		{
-			name: "successful go file generation using extra functions",
+			name: "successful file generation using extra functions",
 			args: args{
 				tg: NewGenerator(),
-				templatesMap: map[string]string{"lambda.go": "{{getFileByName $.Files \"lambda.go\"}} " +
+				templatesMap: map[string]string{"lambda.txt": "{{getFileByName $.Files \"lambda.go\"}} " +
 					"{{ range getFileImports $.Files \"lambda.go\" }}\"{{ . }}\"{{end}}"},
-				fileName:   "lambda.go",
-				outputFile: path.Join(testOutput, "lambda.go"),
+				fileName:   "lambda.txt",
+				outputFile: path.Join(testOutput, "lambda.txt"),
 				data: struct{ Files map[string]File }{
 					Files: map[string]File{"lambda.go": {
 						Imports: []string{"context"},
@@ -64,6 +66,24 @@ func TestMustGenerateFile(t *testing.T) {
 				require.Equal(tb, `{tmpl [context]} "context"`, string(data))
 			},
 		},
+		{
+			name: "successful terraform file generation and formatting",
+			args: args{
+				tg: NewGenerator(),
+				templatesMap: map[string]string{
+					"test.tf": "resource \"aws_sqs_queue\" \"{{.Name}}\" {\nname = \"x\"\nvisibility_timeout_seconds = 1\n}",
+				},
+				fileName:   "test.tf",
+				outputFile: path.Join(testOutput, "nested", "output.tf"),
+				data:       struct{ Name string }{Name: "world"},
+			},
+			extraValidations: func(tb testing.TB, outputFile string) {
+				data, err := os.ReadFile(outputFile)
+				require.NoError(tb, err)
+				require.Equal(tb, "resource \"aws_sqs_queue\" \"world\" {\n  name                       = \"x\"\n"+
+					"  visibility_timeout_seconds = 1\n}", string(data))
+			},
+		},
 		{
 			name: "when file ext is not supported should log a message and the file will not be generated",
 			args: args{
@@ -79,6 +99,36 @@ func TestMustGenerateFile(t *testing.T) {
 				require.Equal(tb, "Hello, World!", string(data))
 			},
 		},
+		{
+			name: "when go file cannot be formatted should return an error and write the unformatted content",
+			args: args{
+				tg:           NewGenerator(),
+				templatesMap: map[string]string{"test.go": "func {{.Name}}("},
+				fileName:     "test.go",
+				outputFile:   path.Join(testOutput, "invalid.go"),
+				data:         struct{ Name string }{Name: "World"},
+			},
+			extraValidations: func(tb testing.TB, outputFile string) {
+				data, err := os.ReadFile(outputFile)
+				require.NoError(tb, err)
+				require.Equal(tb, "func World(", string(data))
+			},
+			wantErr: true,
+		},
+		{
+			name: "when template is invalid should return an error and the file will not be generated",
+			args: args{
+				tg:           NewGenerator(),
+				templatesMap: map[string]string{"test.tf": "{{.Name"},
+				fileName:     "test.tf",
+				outputFile:   path.Join(testOutput, "invalid.tf"),
+				data:         struct{ Name string }{Name: "World"},
+			},
+			extraValidations: func(tb testing.TB, outputFile string) {
+				require.NoFileExists(tb, outputFile)
+			},
+			wantErr: true,
+		},
 	}
 
 	defer func() {
@@ -89,15 +139,16 @@ func TestMustGenerateFile(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			MustGenerateFile(
-				tc.args.tg, tc.args.templatesMap, tc.args.fileName, tc.args.fileTmpl, tc.args.outputFile, tc.args.data)
+			err := GenerateFile(tc.args.tg, filesystem.NewOS(), tc.args.templatesMap, tc.args.fileName, tc.args.fileTmpl,
+				tc.args.outputFile, tc.args.data)
+			require.Equal(t, tc.wantErr, err != nil, err)
 
 			tc.extraValidations(t, tc.args.outputFile)
 		})
 	}
 }
 
-func TestMustGenerateFiles(t *testing.T) {
+func TestGenerateFiles(t *testing.T) {
 	type args struct {
 		tg                  *templategenerators.TemplateGenerator
 		defaultTemplatesMap map[string]string
@@ -132,8 +183,10 @@ func TestMustGenerateFiles(t *testing.T) {
 	}()
 
 	for _, tc := range tests {
-		t.Run(tc.name, func(_ *testing.T) {
-			MustGenerateFiles(tc.args.tg, tc.args.defaultTemplatesMap, tc.args.filesMap, tc.args.data, tc.args.output)
+		t.Run(tc.name, func(t *testing.T) {
+			err := GenerateFiles(tc.args.tg, filesystem.NewOS(), tc.args.defaultTemplatesMap, tc.args.filesMap, tc.args.data,
+				tc.args.output)
+			require.NoError(t, err)
 		})
 	}
 }
@@ -213,6 +266,68 @@ func TestCreateFilesMap(t *testing.T) {
 	}
 }
 
+func TestCreateDependencies(t *testing.T) {
+	type args struct {
+		envars map[string]string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []Dependency
+	}{
+		{
+			name: "empty input",
+			args: args{
+				envars: map[string]string{},
+			},
+			want: []Dependency{},
+		},
+		{
+			name: "kinds from the terraform resources",
+			args: args{
+				envars: map[string]string{
+					"TARGET_SQS_QUEUE_URL":      "aws_sqs_queue.target_sqs.url",
+					"REPORTS":                   "aws_s3_bucket.reports_bucket.bucket",
+					"ORDERS_KINESIS_STREAM_URL": "aws_kinesis_stream.orders_kinesis.name",
+					"ORDERS_DB_HOST":            "var.orders_db_host",
+				},
+			},
+			want: []Dependency{
+				{Name: "ORDERS_KINESIS_STREAM", Envar: "ORDERS_KINESIS_STREAM_URL", Kind: DependencyKinesis},
+				{Name: "REPORTS", Envar: "REPORTS", Kind: DependencyS3},
+				{Name: "TARGET_SQS_QUEUE", Envar: "TARGET_SQS_QUEUE_URL", Kind: DependencySQS},
+			},
+		},
+		{
+			name: "kinds from the names of the variables",
+			args: args{
+				envars: map[string]string{
+					"EVENTS_QUEUE_URL":   "var.events_queue_url",
+					"EVENTS_QUEUE_ARN":   "var.events_queue_arn",
+					"ARCHIVE_BUCKET":     "my-archive",
+					"CLICKS_STREAM_NAME": "clicks",
+				},
+			},
+			want: []Dependency{
+				{Name: "ARCHIVE_BUCKET", Envar: "ARCHIVE_BUCKET", Kind: DependencyS3},
+				{Name: "CLICKS_STREAM", Envar: "CLICKS_STREAM_NAME", Kind: DependencyKinesis},
+				{Name: "EVENTS_QUEUE", Envar: "EVENTS_QUEUE_ARN", Kind: DependencySQS},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got := CreateDependencies(tc.args.envars)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestCreateTemplatesMap(t *testing.T) {
 	type args struct {
 		filenameTemplatesList []config.FilenameTemplateMap
@@ -349,3 +464,24 @@ func TestFilterTemplatesMap(t *testing.T) {
 		})
 	}
 }
+
+func TestQuoteHCL(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{name: "plain string", s: "payments", want: `"payments"`},
+		{name: "quotes and backslashes", s: `say "hi" \o/`, want: `"say \"hi\" \\o/"`},
+		{name: "control characters", s: "a\tb\nc\r", want: `"a\tb\nc\r"`},
+		{name: "template sequences", s: "${var.team} %{if x}", want: `"$${var.team} %%{if x}"`},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, QuoteHCL(tc.s))
+		})
+	}
+}
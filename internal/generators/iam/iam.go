@@ -0,0 +1,327 @@
+package iam
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/diagram-code-generator/resources/pkg/resources"
+	templategenerators "github.com/diagram-code-generator/template/pkg/generators"
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/transformers/yamltoresources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+var (
+	// Actions allowed when a Lambda sends messages to an SQS queue.
+	sqsProducerActions = []string{"sqs:GetQueueAttributes", "sqs:GetQueueUrl", "sqs:SendMessage"}
+
+	// Actions allowed when an SQS queue triggers a Lambda.
+	sqsConsumerActions = []string{
+		"sqs:ChangeMessageVisibility", "sqs:DeleteMessage", "sqs:GetQueueAttributes", "sqs:ReceiveMessage",
+	}
+
+	// Actions allowed when a Lambda puts records into a Kinesis stream.
+	kinesisProducerActions = []string{"kinesis:DescribeStream", "kinesis:PutRecord", "kinesis:PutRecords"}
+
+	// Actions allowed when a Kinesis stream triggers a Lambda.
+	kinesisConsumerActions = []string{
+		"kinesis:DescribeStream", "kinesis:DescribeStreamSummary", "kinesis:GetRecords", "kinesis:GetShardIterator",
+		"kinesis:ListShards",
+	}
+
+	// Actions allowed when a Lambda runs in a VPC, to manage its network interfaces.
+	vpcActions = []string{
+		"ec2:AssignPrivateIpAddresses", "ec2:CreateNetworkInterface", "ec2:DeleteNetworkInterface",
+		"ec2:DescribeNetworkInterfaces", "ec2:UnassignPrivateIpAddresses",
+	}
+
+	// Actions allowed when a Lambda sends its traces to X-Ray.
+	xrayActions = []string{"xray:PutTelemetryRecords", "xray:PutTraceSegments"}
+
+	// Actions allowed when a Lambda sends its failed events to an SNS topic.
+	snsDeadLetterActions = []string{"sns:Publish"}
+
+	// Actions allowed when a Lambda sends its failed events to an SQS queue.
+	sqsDeadLetterActions = []string{"sqs:SendMessage"}
+
+	// Actions allowed when a Lambda reads and writes objects of an S3 bucket.
+	s3Actions = []string{"s3:DeleteObject", "s3:GetObject", "s3:ListBucket", "s3:PutObject"}
+
+	// Actions allowed when a Lambda sends messages to the clients of a WebSocket API, or disconnects them.
+	manageConnectionsActions = []string{"execute-api:ManageConnections"}
+
+	// Actions allowed when a Lambda keeps the connections of the clients of a WebSocket API.
+	connectionTableActions = []string{"dynamodb:DeleteItem", "dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:Scan"}
+
+	// Actions allowed when a Lambda accesses a DynamoDB table.
+	dynamoDBActions = []string{
+		"dynamodb:BatchGetItem", "dynamodb:BatchWriteItem", "dynamodb:DeleteItem", "dynamodb:GetItem",
+		"dynamodb:PutItem", "dynamodb:Query", "dynamodb:Scan", "dynamodb:UpdateItem",
+	}
+)
+
+// Terraform labels of the queues, streams and buckets a Lambda sends data to.
+var labelByProducerType = map[awsresources.ResourceType]string{
+	awsresources.SQSType:     awsresources.LabelAWSSQSQueue,
+	awsresources.KinesisType: awsresources.LabelAWSKinesisStream,
+	awsresources.S3Type:      awsresources.LabelAWSS3Bucket,
+}
+
+type StatementData struct {
+	Actions   string
+	Resources string
+}
+
+type Data struct {
+	Name       string
+	RoleName   string
+	Statements []StatementData
+	Tags       map[string]string
+}
+
+// Policies holds the least-privilege statements of every Lambda, derived from the relationships of the resource graph.
+type Policies struct {
+	statementsByLambda map[string][]StatementData
+}
+
+// NewPolicies transforms the configuration into a resource graph and walks its relationships to find out which
+// actions each Lambda needs. The environment variables referring to a Terraform resource that the configuration does
+// not declare are granted access to it as well. The triggers of the Lambdas are read from the configuration, so their
+// source ARNs are used as they are, whether they are Terraform references or literal ARNs of existing resources.
+func NewPolicies(yamlConfig *config.Config) (*Policies, error) {
+	resc, err := yamltoresources.NewTransformer(yamlConfig, yamltoresources.WithoutWarnings()).Transform()
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	p := &Policies{statementsByLambda: map[string][]StatementData{}}
+
+	seen := map[string]struct{}{}
+
+	add := func(lambda string, statement StatementData) {
+		key := lambda + statement.Actions + statement.Resources
+		if _, ok := seen[key]; ok {
+			return
+		}
+
+		seen[key] = struct{}{}
+
+		p.statementsByLambda[lambda] = append(p.statementsByLambda[lambda], statement)
+	}
+
+	tables := map[string]struct{}{}
+	for i := range yamlConfig.DynamoDBs {
+		tables[strcase.ToSnake(yamlConfig.DynamoDBs[i].Name)] = struct{}{}
+	}
+
+	for _, rel := range resc.Relationships {
+		if rel.Source == nil || rel.Target == nil {
+			continue
+		}
+
+		lambda, statement, ok := buildStatement(rel.Source, rel.Target, tables)
+		if !ok {
+			continue
+		}
+
+		add(lambda, statement)
+	}
+
+	addEnvars := func(lambdaName string, envars map[string]string) {
+		for key, value := range envars {
+			if statement, ok := envarStatement(key, value); ok {
+				add(awsresources.ToLambdaCase(lambdaName), statement)
+			}
+		}
+	}
+
+	for i := range yamlConfig.APIGateways {
+		for j := range yamlConfig.APIGateways[i].Lambdas {
+			lambdaConf := &yamlConfig.APIGateways[i].Lambdas[j]
+			addEnvars(lambdaConf.Name, lambdaConf.Envars)
+		}
+	}
+
+	for i := range yamlConfig.Lambdas {
+		lambdaConf := &yamlConfig.Lambdas[i]
+		lambda := awsresources.ToLambdaCase(lambdaConf.Name)
+
+		addEnvars(lambdaConf.Name, lambdaConf.Envars)
+
+		for j := range lambdaConf.KinesisTriggers {
+			add(lambda, newStatement(kinesisConsumerActions,
+				generators.QuoteIfLiteral(lambdaConf.KinesisTriggers[j].SourceARN)))
+		}
+
+		for j := range lambdaConf.SQSTriggers {
+			add(lambda, newStatement(sqsConsumerActions, generators.QuoteIfLiteral(lambdaConf.SQSTriggers[j].SourceARN)))
+		}
+	}
+
+	for lambda := range p.statementsByLambda {
+		statements := p.statementsByLambda[lambda]
+		sort.Slice(statements, func(i, j int) bool {
+			return statements[i].Resources+statements[i].Actions < statements[j].Resources+statements[j].Actions
+		})
+	}
+
+	return p, nil
+}
+
+// Data returns the template data of the role generated for the Lambda.
+func (p *Policies) Data(lambdaName string) Data {
+	return Data{
+		Name:       lambdaName,
+		RoleName:   RoleName(lambdaName),
+		Statements: slices.Clone(p.statementsByLambda[awsresources.ToLambdaCase(lambdaName)]),
+	}
+}
+
+// FunctionStatements returns the statements required by the settings of the function: the network interfaces of a
+// VPC, the X-Ray traces and the dead-letter target.
+func FunctionStatements(function *config.LambdaFunction) []StatementData {
+	var statements []StatementData
+
+	if len(function.VPC.SubnetIDs) > 0 || len(function.VPC.SecurityGroupIDs) > 0 {
+		statements = append(statements, newStatement(vpcActions, `"*"`))
+	}
+
+	if function.TracingMode == config.LambdaTracingModeActive {
+		statements = append(statements, newStatement(xrayActions, `"*"`))
+	}
+
+	if function.DeadLetterTargetARN != "" {
+		actions := sqsDeadLetterActions
+		if strings.HasPrefix(function.DeadLetterTargetARN, "aws_sns_topic.") ||
+			strings.HasPrefix(function.DeadLetterTargetARN, "arn:aws:sns:") {
+			actions = snsDeadLetterActions
+		}
+
+		statements = append(statements, newStatement(actions, generators.QuoteIfLiteral(function.DeadLetterTargetARN)))
+	}
+
+	return statements
+}
+
+// WebSocketStatements returns the statements required by the handlers of a WebSocket API: managing the connections
+// of the API, and keeping them in its connection table. Both ARNs are Terraform references.
+func WebSocketStatements(apiExecutionARN, connectionTableARN string) []StatementData {
+	return []StatementData{
+		newStatement(manageConnectionsActions, fmt.Sprintf(`"${%s}/*"`, apiExecutionARN)),
+		newStatement(connectionTableActions, connectionTableARN),
+	}
+}
+
+// RoleName returns the name of the role generated for the Lambda.
+func RoleName(lambdaName string) string {
+	return fmt.Sprintf("%s_lambda_role", strcase.ToSnake(lambdaName))
+}
+
+// Template returns the IAM template, taking into account the overridden default templates.
+func Template(yamlConfig *config.Config) string {
+	return utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.IAM))[filenameIAMtf]
+}
+
+// GenerateRole generates the role of the Lambda in the '<lambda>-iam.tf' file of the output folder.
+func GenerateRole(
+	tg *templategenerators.TemplateGenerator, fs filesystem.FileSystem, tmpl string, data Data, output string,
+) error {
+	fileName := fmt.Sprintf("%s-iam.tf", data.Name)
+
+	if err := generators.GenerateFile(tg, fs, nil, fileName, tmpl, path.Join(output, fileName), data); err != nil {
+		return err
+	}
+
+	fmtcolor.White.Printf("IAM role '%s' has been generated successfully\n", data.RoleName)
+
+	return nil
+}
+
+// buildStatement returns the statement of the Lambda that sends data to the target. The resources not declared in the
+// configuration, like the databases other than its DynamoDB tables, have no Terraform reference, so they are skipped.
+func buildStatement(
+	source, target resources.Resource, tables map[string]struct{},
+) (lambda string, statement StatementData, ok bool) {
+	if awsresources.ParseResourceType(source.ResourceType()) != awsresources.LambdaType {
+		return "", StatementData{}, false
+	}
+
+	lambda = source.Value()
+
+	switch resType := awsresources.ParseResourceType(target.ResourceType()); resType {
+	case awsresources.SQSType, awsresources.KinesisType, awsresources.S3Type:
+		statement = producerStatement(resType, resourceARN(target, labelByProducerType[resType]))
+	case awsresources.DatabaseType, awsresources.DynamoDBType:
+		if _, ok := tables[strcase.ToSnake(target.Value())]; !ok {
+			return "", StatementData{}, false
+		}
+
+		arn := resourceARN(target, awsresources.LabelAWSDynamoDBTable)
+		statement = newStatement(dynamoDBActions, arn, fmt.Sprintf(`"${%s}/index/*"`, arn))
+	default:
+		return "", StatementData{}, false
+	}
+
+	return lambda, statement, true
+}
+
+// envarStatement returns the statement of the Lambda that sends data to the queue, stream or bucket its environment
+// variable refers to, classified like the dependencies of its code. Only the Terraform references have a known ARN, so
+// the other values are skipped.
+func envarStatement(key, value string) (StatementData, bool) {
+	resType := awsresources.EnvarResourceType(key, value)
+
+	label, ok := labelByProducerType[resType]
+	if !ok {
+		return StatementData{}, false
+	}
+
+	arn := awsresources.ParseResourceARN(value, resType)
+	if arn.Type != label || arn.Label == "" {
+		return StatementData{}, false
+	}
+
+	return producerStatement(resType, fmt.Sprintf("%s.%s.arn", arn.Type, arn.Label)), true
+}
+
+// producerStatement returns the statement of a Lambda that sends data to the queue, stream or bucket with the ARN.
+func producerStatement(resType awsresources.ResourceType, arn string) StatementData {
+	switch resType {
+	case awsresources.SQSType:
+		return newStatement(sqsProducerActions, arn)
+	case awsresources.KinesisType:
+		return newStatement(kinesisProducerActions, arn)
+	default:
+		return newStatement(s3Actions, arn, fmt.Sprintf(`"${%s}/*"`, arn))
+	}
+}
+
+func newStatement(actions []string, resourceARNs ...string) StatementData {
+	quotedActions := make([]string, 0, len(actions))
+	for _, action := range actions {
+		quotedActions = append(quotedActions, fmt.Sprintf("%q", action))
+	}
+
+	return StatementData{
+		Actions:   strings.Join(quotedActions, ", "),
+		Resources: strings.Join(resourceARNs, ", "),
+	}
+}
+
+// resourceARN returns the Terraform reference to the ARN of the resource, following the labels used by the generators.
+func resourceARN(res resources.Resource, resourceLabel string) string {
+	suffix := awsresources.SuffixByResource[awsresources.ParseResourceType(res.ResourceType())]
+
+	return fmt.Sprintf("%s.%s_%s.arn", resourceLabel, strcase.ToSnake(res.Value()), suffix)
+}
@@ -0,0 +1,141 @@
+package iam
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	"github.com/joselitofilho/aws-terraform-generator/internal/transformers/yamltoresources"
+)
+
+var testdataFolder = "../testdata"
+
+func TestNewPolicies(t *testing.T) {
+	yamlConfig, err := config.NewYAML(path.Join(testdataFolder, "iam.config.yaml")).Parse()
+	require.NoError(t, err)
+
+	type args struct {
+		yamlConfig *config.Config
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		lambdaName string
+		want       Data
+		targetErr  error
+	}{
+		{
+			name:       "lambda with triggers and downstream resources",
+			args:       args{yamlConfig: yamlConfig},
+			lambdaName: "orderProcessor",
+			want: Data{
+				Name:     "orderProcessor",
+				RoleName: "order_processor_lambda_role",
+				Statements: []StatementData{
+					newStatement(sqsConsumerActions, `"arn:aws:sqs:us-east-1:123456789012:target"`),
+					newStatement(dynamoDBActions, "aws_dynamodb_table.orders_dynamodb.arn",
+						`"${aws_dynamodb_table.orders_dynamodb.arn}/index/*"`),
+					newStatement(kinesisConsumerActions, "aws_kinesis_stream.orders_kinesis.arn"),
+					newStatement(s3Actions, "aws_s3_bucket.reports_bucket.arn",
+						`"${aws_s3_bucket.reports_bucket.arn}/*"`),
+					newStatement(sqsConsumerActions, "aws_sqs_queue.source_sqs.arn"),
+					newStatement(sqsProducerActions, "aws_sqs_queue.target_sqs.arn"),
+				},
+			},
+		},
+		{
+			name:       "apigateway lambda",
+			args:       args{yamlConfig: yamlConfig},
+			lambdaName: "ordersAPI",
+			want: Data{
+				Name:       "ordersAPI",
+				RoleName:   "orders_api_lambda_role",
+				Statements: []StatementData{newStatement(kinesisProducerActions, "aws_kinesis_stream.orders_kinesis.arn")},
+			},
+		},
+		{
+			name:       "lambda with environment variables referring to undeclared resources",
+			args:       args{yamlConfig: yamlConfig},
+			lambdaName: "eventPublisher",
+			want: Data{
+				Name:     "eventPublisher",
+				RoleName: "event_publisher_lambda_role",
+				Statements: []StatementData{
+					newStatement(kinesisProducerActions, "aws_kinesis_stream.events.arn"),
+					newStatement(sqsProducerActions, "aws_sqs_queue.orders_sqs.arn"),
+				},
+			},
+		},
+		{
+			name:       "lambda without relationships",
+			args:       args{yamlConfig: yamlConfig},
+			lambdaName: "exampleReceiver",
+			want:       Data{Name: "exampleReceiver", RoleName: "example_receiver_lambda_role"},
+		},
+		{
+			name:      "empty config",
+			args:      args{yamlConfig: nil},
+			targetErr: yamltoresources.ErrEmptyConfig,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			policies, err := NewPolicies(tc.args.yamlConfig)
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if err != nil {
+				return
+			}
+
+			require.Equal(t, tc.want, policies.Data(tc.lambdaName))
+		})
+	}
+}
+
+func TestFunctionStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		function config.LambdaFunction
+		want     []StatementData
+	}{
+		{
+			name: "vpc, active tracing and sqs dead-letter target",
+			function: config.LambdaFunction{
+				TracingMode:         config.LambdaTracingModeActive,
+				DeadLetterTargetARN: "aws_sqs_queue.failed_sqs.arn",
+				VPC:                 config.LambdaVPC{SubnetIDs: []string{"subnet-1"}},
+			},
+			want: []StatementData{
+				newStatement(vpcActions, `"*"`),
+				newStatement(xrayActions, `"*"`),
+				newStatement(sqsDeadLetterActions, "aws_sqs_queue.failed_sqs.arn"),
+			},
+		},
+		{
+			name:     "sns dead-letter target",
+			function: config.LambdaFunction{DeadLetterTargetARN: "arn:aws:sns:us-east-1:123456789012:failed"},
+			want: []StatementData{
+				newStatement(snsDeadLetterActions, `"arn:aws:sns:us-east-1:123456789012:failed"`),
+			},
+		},
+		{
+			name:     "pass through tracing",
+			function: config.LambdaFunction{TracingMode: config.LambdaTracingModePassThrough},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, FunctionStatements(&tc.function))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package iam
+
+import (
+	_ "embed"
+)
+
+const filenameIAMtf = "iam.tf"
+
+var (
+	//go:embed tmpls/iam.tf.tmpl
+	iamTfTmpl []byte
+)
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameIAMtf: string(iamTfTmpl),
+}
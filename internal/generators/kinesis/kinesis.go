@@ -2,11 +2,13 @@ package kinesis
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -19,19 +21,29 @@ type Data struct {
 	KMSEncription   bool
 	RetentionPeriod string
 	KMSKeyID        string
+	Tags            map[string]string
 }
 
 type Kinesis struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewKinesis(configFileName, output string) *Kinesis {
-	return &Kinesis{configFileName: configFileName, output: output}
+func NewKinesis(configFileName, output string, opts ...generators.Option) *Kinesis {
+	options := generators.NewOptions(opts...)
+
+	return &Kinesis{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (k *Kinesis) Build() error {
-	yamlParser := config.NewYAML(k.configFileName)
+	yamlParser := config.NewYAML(k.configFileName, k.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -39,15 +51,16 @@ func (k *Kinesis) Build() error {
 	}
 
 	modPath := path.Join(k.output, "mod")
-	_ = os.MkdirAll(modPath, os.ModePerm)
 
 	result := make([]string, 0, len(yamlConfig.Kinesis))
 
-	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Kinesis))
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.Kinesis {
 		conf := yamlConfig.Kinesis[i]
 
@@ -56,12 +69,16 @@ func (k *Kinesis) Build() error {
 			KMSEncription:   conf.KMSKeyID != "",
 			RetentionPeriod: conf.RetentionPeriod,
 			KMSKeyID:        conf.KMSKeyID,
+			Tags:            yamlConfig.ResourceTags(conf.Tags),
 		}
 
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
-			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+			if err := generators.GenerateFiles(tg, k.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
 			fmtcolor.White.Printf("Kinesis '%s' has been generated successfully\n", conf.Name)
 
@@ -79,10 +96,13 @@ func (k *Kinesis) Build() error {
 	if len(result) > 0 {
 		outputFile := path.Join(modPath, filenameKinesisTf)
 
-		generators.MustGenerateFile(tg, nil, filenameKinesisTf, strings.Join(result, "\n"), outputFile, Data{})
+		err := generators.GenerateFile(tg, k.fs, nil, filenameKinesisTf, strings.Join(result, "\n"), outputFile, Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
 
 		fmtcolor.White.Println("Kinesis has been generated successfully")
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
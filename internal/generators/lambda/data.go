@@ -6,29 +6,84 @@ import (
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 )
 
+const (
+	// TriggerNone is the trigger of a Lambda that is not triggered by any event source.
+	TriggerNone = ""
+
+	// TriggerSQS is the trigger of a Lambda that is only triggered by SQS queues.
+	TriggerSQS = "sqs"
+
+	// TriggerKinesis is the trigger of a Lambda that is only triggered by Kinesis streams.
+	TriggerKinesis = "kinesis"
+
+	// TriggerCron is the trigger of a Lambda that is only triggered by crons.
+	TriggerCron = "cron"
+
+	// TriggerMixed is the trigger of a Lambda that is triggered by more than one kind of event source.
+	TriggerMixed = "mixed"
+)
+
 type KinesisTrigger struct {
-	SourceARN string
+	SourceARN                      string
+	Suffix                         string
+	BatchSize                      int
+	StartingPosition               string
+	MaximumBatchingWindowInSeconds int
+	ParallelizationFactor          int
+	BisectBatchOnFunctionError     bool
+	FilterPatterns                 []string
+	PartialBatchResponse           bool
 }
 
 type SQSTrigger struct {
-	SourceARN string
+	SourceARN                      string
+	Suffix                         string
+	BatchSize                      int
+	MaximumBatchingWindowInSeconds int
+	MaximumConcurrency             int
+	FilterPatterns                 []string
+	PartialBatchResponse           bool
 }
 
 type Cron struct {
 	ScheduleExpression string
 	IsEnabled          string
+	Input              string
+	Suffix             string
+}
+
+// Schedule is a cron in the scheduler mode, generated as an EventBridge Scheduler schedule.
+type Schedule struct {
+	ScheduleExpression     string
+	Timezone               string
+	State                  string
+	MaximumWindowInMinutes int
+	StartDate              string
+	EndDate                string
+	Input                  string
+	Suffix                 string
 }
 
 type Data struct {
+	generators.FunctionData
+
 	Name            string
 	AsModule        bool
 	Source          string
 	RoleName        string
 	Runtime         string
+	Handler         string
 	Description     string
 	Envars          map[string]string
 	KinesisTriggers []KinesisTrigger
 	SQSTriggers     []SQSTrigger
 	Crons           []Cron
-	Files           map[string]generators.File
+	Schedules       []Schedule
+	Trigger         string
+	// PartialBatchResponse is true when every SQS trigger of the Lambda reports the messages that failed, so its
+	// code returns them instead of failing the whole batch.
+	PartialBatchResponse bool
+	Dependencies         []generators.Dependency
+	Tags                 map[string]string
+	Files                map[string]generators.File
 }
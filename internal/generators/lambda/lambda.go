@@ -2,47 +2,78 @@ package lambda
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/iam"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
+// Number of records sent to the Lambda at once, unless the trigger sets it.
+const defaultBatchSize = 1
+
 type Lambda struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewLambda(configFileName, output string) *Lambda {
-	return &Lambda{configFileName: configFileName, output: output}
+func NewLambda(configFileName, output string, opts ...generators.Option) *Lambda {
+	options := generators.NewOptions(opts...)
+
+	return &Lambda{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (l *Lambda) Build() error {
-	yamlParser := config.NewYAML(l.configFileName)
+	yamlParser := config.NewYAML(l.configFileName, l.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
 
-	tfTemplates := utils.MergeStringMap(defaultTfTemplatesMap,
+	tfTemplates := utils.MergeStringMap(maps.Clone(defaultTfTemplatesMap),
 		generators.FilterTemplatesMap(".tf", generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Lambda)))
 
-	goTemplates := utils.MergeStringMap(defaultGoTemplatesMap,
-		generators.FilterTemplatesMap(".go", generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Lambda)))
+	overrideTemplates := generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Lambda)
+
+	codeTemplates := map[string]map[string]string{}
+	for family, templates := range defaultCodeTemplatesMap {
+		codeTemplates[family] = utils.MergeStringMap(maps.Clone(templates),
+			generators.FilterRuntimeTemplatesMap(family, overrideTemplates))
+	}
+
+	policies, err := iam.NewPolicies(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	iamTfTemplate := iam.Template(yamlConfig)
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.Lambdas {
 		lambdaConf := yamlConfig.Lambdas[i]
 
-		crons := buildCrons(&lambdaConf)
+		crons, schedules := buildCrons(&lambdaConf)
 		kinesisTriggers := buildKinesisTriggers(&lambdaConf)
 		sqsTriggers := buildSQSTriggers(&lambdaConf)
 
@@ -50,75 +81,201 @@ func (l *Lambda) Build() error {
 
 		asModule := strings.Contains(lambdaConf.Source, "git@")
 
+		output := path.Join(l.output, "mod")
+
+		tags := yamlConfig.ResourceTags(lambdaConf.Tags)
+
 		roleName := lambdaConf.RoleName
 		if roleName == "" {
-			roleName = "iam_for_lambda"
+			roleData := policies.Data(lambdaConf.Name)
+			roleData.Tags = tags
+			roleData.Statements = append(roleData.Statements, iam.FunctionStatements(&lambdaConf.LambdaFunction)...)
+			roleName = roleData.RoleName
+
+			errs = append(errs, iam.GenerateRole(tg, l.fs, iamTfTemplate, roleData, output))
 		}
 
 		data := Data{
-			Name:            lambdaConf.Name,
-			AsModule:        asModule,
-			Source:          lambdaConf.Source,
-			RoleName:        roleName,
-			Runtime:         lambdaConf.Runtime,
-			Description:     lambdaConf.Description,
-			Envars:          lambdaConf.Envars,
-			KinesisTriggers: kinesisTriggers,
-			SQSTriggers:     sqsTriggers,
-			Crons:           crons,
-			Files:           filesConf,
+			Name:                 lambdaConf.Name,
+			AsModule:             asModule,
+			Source:               lambdaConf.Source,
+			RoleName:             roleName,
+			Runtime:              lambdaConf.Runtime,
+			Handler:              generators.RuntimeHandler(lambdaConf.Runtime, strcase.ToSnake(lambdaConf.Name)),
+			Description:          lambdaConf.Description,
+			Envars:               lambdaConf.Envars,
+			FunctionData:         generators.NewFunctionData(&lambdaConf.LambdaFunction),
+			KinesisTriggers:      kinesisTriggers,
+			SQSTriggers:          sqsTriggers,
+			Crons:                crons,
+			Schedules:            schedules,
+			Trigger:              trigger(&lambdaConf),
+			PartialBatchResponse: sqsPartialBatchResponse(&lambdaConf),
+			Dependencies:         generators.CreateDependencies(lambdaConf.Envars),
+			Tags:                 tags,
+			Files:                filesConf,
 		}
 
-		output := path.Join(l.output, "mod")
-		_ = os.MkdirAll(output, os.ModePerm)
-
 		outputFile := path.Join(output, lambdaConf.Name+".tf")
 
-		generators.MustGenerateFile(tg, tfTemplates, filenameTfLambda, "", outputFile, data)
-
-		fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", lambdaConf.Name)
+		if err := generators.GenerateFile(tg, l.fs, tfTemplates, filenameTfLambda, "", outputFile, data); err != nil {
+			errs = append(errs, err)
+		} else {
+			fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", lambdaConf.Name)
+		}
 
 		output = fmt.Sprintf("%s/lambda/%s", l.output, lambdaConf.Name)
-		_ = os.MkdirAll(output, os.ModePerm)
 
-		generators.MustGenerateFiles(tg, goTemplates, filesConf, data, output)
+		err := generators.GenerateFiles(tg, l.fs, codeTemplates[generators.RuntimeFamily(lambdaConf.Runtime)],
+			filesConf, data, output)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 
 		fmtcolor.White.Printf("Lambda '%s' has been generated successfully\n", lambdaConf.Name)
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// trigger returns the kind of event source that triggers the Lambda, which defines the signature of its handler.
+func trigger(lambdaConf *config.Lambda) string {
+	var triggers []string
+
+	if len(lambdaConf.SQSTriggers) > 0 {
+		triggers = append(triggers, TriggerSQS)
+	}
+
+	if len(lambdaConf.KinesisTriggers) > 0 {
+		triggers = append(triggers, TriggerKinesis)
+	}
+
+	if len(lambdaConf.Crons) > 0 {
+		triggers = append(triggers, TriggerCron)
+	}
+
+	switch len(triggers) {
+	case 0:
+		return TriggerNone
+	case 1:
+		return triggers[0]
+	default:
+		return TriggerMixed
+	}
 }
 
-func buildCrons(lambdaConf *config.Lambda) []Cron {
-	crons := make([]Cron, len(lambdaConf.Crons))
+// sqsPartialBatchResponse returns true when the Lambda has SQS triggers and all of them report the failed messages.
+func sqsPartialBatchResponse(lambdaConf *config.Lambda) bool {
+	for i := range lambdaConf.SQSTriggers {
+		if !lambdaConf.SQSTriggers[i].PartialBatchResponse {
+			return false
+		}
+	}
+
+	return len(lambdaConf.SQSTriggers) > 0
+}
+
+// buildCrons returns the crons generated as CloudWatch event rules and the ones generated as EventBridge Scheduler
+// schedules. Each kind is numbered on its own, so the names of the resources stay unique.
+func buildCrons(lambdaConf *config.Lambda) (crons []Cron, schedules []Schedule) {
 	for i := range lambdaConf.Crons {
-		crons[i] = Cron{
-			ScheduleExpression: lambdaConf.Crons[i].ScheduleExpression,
-			IsEnabled:          lambdaConf.Crons[i].IsEnabled,
+		conf := &lambdaConf.Crons[i]
+
+		isEnabled := conf.IsEnabled
+		if isEnabled == "" {
+			isEnabled = "true"
+		}
+
+		var input string
+		if conf.Input != "" {
+			input = fmt.Sprintf("%q", conf.Input)
 		}
+
+		if !conf.IsScheduler() {
+			crons = append(crons, Cron{
+				ScheduleExpression: conf.ScheduleExpression,
+				IsEnabled:          isEnabled,
+				Input:              input,
+				Suffix:             generators.Suffix(len(crons)),
+			})
+
+			continue
+		}
+
+		schedules = append(schedules, Schedule{
+			ScheduleExpression:     conf.ScheduleExpression,
+			Timezone:               conf.Timezone,
+			State:                  scheduleState(isEnabled),
+			MaximumWindowInMinutes: conf.FlexibleTimeWindow,
+			StartDate:              conf.StartDate,
+			EndDate:                conf.EndDate,
+			Input:                  input,
+			Suffix:                 generators.Suffix(len(schedules)),
+		})
 	}
 
-	return crons
+	return crons, schedules
+}
+
+// scheduleState returns the state of a schedule as a Terraform expression, from the is_enabled of its cron.
+func scheduleState(isEnabled string) string {
+	switch isEnabled {
+	case "true":
+		return `"ENABLED"`
+	case "false":
+		return `"DISABLED"`
+	default:
+		return fmt.Sprintf(`%s ? "ENABLED" : "DISABLED"`, isEnabled)
+	}
 }
 
 func buildKinesisTriggers(lambdaConf *config.Lambda) []KinesisTrigger {
 	kinesisTriggers := make([]KinesisTrigger, len(lambdaConf.KinesisTriggers))
 	for i := range lambdaConf.KinesisTriggers {
+		conf := &lambdaConf.KinesisTriggers[i]
+
 		kinesisTriggers[i] = KinesisTrigger{
-			SourceARN: lambdaConf.KinesisTriggers[i].SourceARN,
+			SourceARN:                      conf.SourceARN,
+			Suffix:                         generators.Suffix(i),
+			BatchSize:                      batchSizeOrDefault(conf.BatchSize),
+			StartingPosition:               conf.GetStartingPosition(),
+			MaximumBatchingWindowInSeconds: conf.MaximumBatchingWindowInSeconds,
+			ParallelizationFactor:          conf.ParallelizationFactor,
+			BisectBatchOnFunctionError:     conf.BisectBatchOnFunctionError,
+			FilterPatterns:                 conf.FilterPatterns,
+			PartialBatchResponse:           conf.PartialBatchResponse,
 		}
 	}
 
 	return kinesisTriggers
 }
 
+// buildSQSTriggers returns the SQS triggers. ReportBatchItemFailures is only set for the triggers with
+// partial_batch_response, which the generated handlers follow to report the messages that failed.
 func buildSQSTriggers(lambdaConf *config.Lambda) []SQSTrigger {
 	sqsTriggers := make([]SQSTrigger, len(lambdaConf.SQSTriggers))
 	for i := range lambdaConf.SQSTriggers {
+		conf := &lambdaConf.SQSTriggers[i]
+
 		sqsTriggers[i] = SQSTrigger{
-			SourceARN: lambdaConf.SQSTriggers[i].SourceARN,
+			SourceARN:                      conf.SourceARN,
+			Suffix:                         generators.Suffix(i),
+			BatchSize:                      batchSizeOrDefault(conf.BatchSize),
+			MaximumBatchingWindowInSeconds: conf.MaximumBatchingWindowInSeconds,
+			MaximumConcurrency:             conf.MaximumConcurrency,
+			FilterPatterns:                 conf.FilterPatterns,
+			PartialBatchResponse:           conf.PartialBatchResponse,
 		}
 	}
 
 	return sqsTriggers
 }
+
+func batchSizeOrDefault(batchSize int) int {
+	if batchSize <= 0 {
+		return defaultBatchSize
+	}
+
+	return batchSize
+}
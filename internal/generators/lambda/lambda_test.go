@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
@@ -43,10 +44,232 @@ func TestLambda_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(modPath, "exampleReceiver.tf"))
 
 				lambdaPath := path.Join(output, "lambda", "exampleReceiver")
+				require.FileExists(tb, path.Join(lambdaPath, "config.go"))
+				require.FileExists(tb, path.Join(lambdaPath, "dependencies.go"))
 				require.FileExists(tb, path.Join(lambdaPath, "lambda.go"))
+				require.FileExists(tb, path.Join(lambdaPath, "lambda_test.go"))
 				require.FileExists(tb, path.Join(lambdaPath, "main.go"))
 			},
 		},
+		{
+			name: "lambda without role name should generate its role",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "iam.config.yaml"),
+				output:         path.Join(testOutput, "iam", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.NoFileExists(tb, path.Join(modPath, "exampleReceiver-iam.tf"))
+
+				iamTfData, err := os.ReadFile(path.Join(modPath, "orderProcessor-iam.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(iamTfData), `resource "aws_iam_role" "order_processor_lambda_role"`)
+				require.Contains(tb, string(iamTfData),
+					`name = "${var.client}-${var.environment}-order_processor_lambda_role"`)
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "orderProcessor.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), "aws_iam_role.order_processor_lambda_role.arn")
+			},
+		},
+		{
+			name: "lambda handler signature should follow its trigger",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.triggers.yaml"),
+				output:         path.Join(testOutput, "triggers", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsLambdaGoData, err := os.ReadFile(path.Join(output, "lambda", "orderProcessor", "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(sqsLambdaGoData),
+					"run(ctx context.Context, event events.SQSEvent) (events.SQSEventResponse, error)")
+
+				configGoData, err := os.ReadFile(path.Join(output, "lambda", "orderProcessor", "config.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(configGoData), `lookupEnv("TARGET_SQS_QUEUE_URL", &cfg.TargetSqsQueueUrl)`)
+
+				dependenciesGoData, err := os.ReadFile(path.Join(output, "lambda", "orderProcessor", "dependencies.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(dependenciesGoData), "type sqsQueue interface")
+				require.Contains(tb, string(dependenciesGoData), "TargetSqsQueue sqsQueue // TARGET_SQS_QUEUE_URL")
+
+				lambdaTestGoData, err := os.ReadFile(path.Join(output, "lambda", "orderProcessor", "lambda_test.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTestGoData), "event        events.SQSEvent")
+				require.Contains(tb, string(lambdaTestGoData), "TargetSqsQueue: &fakeSQSQueue{},")
+
+				sqsLambdaTfData, err := os.ReadFile(path.Join(output, "mod", "orderProcessor.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(sqsLambdaTfData), `function_response_types = ["ReportBatchItemFailures"]`)
+
+				cronLambdaGoData, err := os.ReadFile(path.Join(output, "lambda", "reportScheduler", "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(cronLambdaGoData), "run(ctx context.Context, event events.CloudWatchEvent) error")
+			},
+		},
+		{
+			name: "multiple triggers and crons should have unique names and their own options",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.eventsources.yaml"),
+				output:         path.Join(testOutput, "eventsources", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				data, err := os.ReadFile(path.Join(output, "mod", "orderProcessor.tf"))
+				require.NoError(tb, err)
+
+				content := string(data)
+				require.Contains(tb, content, `"order_processor_lambda_sqs_trigger" {`)
+				require.Contains(tb, content, `"order_processor_lambda_sqs_trigger_2" {`)
+				require.Contains(tb, content, `batch_size       = 10`)
+				require.Contains(tb, content, `maximum_batching_window_in_seconds = 5`)
+				require.Contains(tb, content, `maximum_concurrency = 20`)
+				require.Contains(tb, content, `pattern = "{\"body\": {\"type\": [\"refund\"]}}"`)
+				require.Equal(tb, 2, strings.Count(content, `function_response_types = ["ReportBatchItemFailures"]`))
+
+				require.Contains(tb, content, `"order_processor_kinesis_mapping" {`)
+				require.Contains(tb, content, `"order_processor_kinesis_mapping_2" {`)
+				require.Contains(tb, content, `starting_position = "LATEST"`)
+				require.Contains(tb, content, `starting_position = "TRIM_HORIZON"`)
+				require.Contains(tb, content, `parallelization_factor = 2`)
+				require.Contains(tb, content, `bisect_batch_on_function_error = true`)
+
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "order_processor_cron" {`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "order_processor_cron_2" {`)
+				require.Contains(tb, content, `statement_id  = "AllowExecutionFromCloudWatch_2"`)
+				require.Contains(tb, content, `name                = "runOrderProcessor_2"`)
+			},
+		},
+		{
+			name: "crons in the scheduler mode should be generated as EventBridge Scheduler schedules",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.scheduler.yaml"),
+				output:         path.Join(testOutput, "scheduler", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				data, err := os.ReadFile(path.Join(output, "mod", "reportScheduler.tf"))
+				require.NoError(tb, err)
+
+				content := string(data)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "report_scheduler_cron" {`)
+				require.Contains(tb, content, `is_enabled          = true`)
+				require.NotContains(tb, content, `"report_scheduler_cron_2"`)
+
+				require.Contains(tb, content, `resource "aws_iam_role" "report_scheduler_scheduler_role" {`)
+				require.Contains(tb, content, `Principal = { Service = "scheduler.amazonaws.com" }`)
+				require.Contains(tb, content, `resource "aws_scheduler_schedule" "report_scheduler_schedule" {`)
+				require.Contains(tb, content, `schedule_expression_timezone = "Europe/Amsterdam"`)
+				require.Contains(tb, content, `start_date                   = "2030-01-01T00:00:00Z"`)
+				require.Contains(tb, content, `maximum_window_in_minutes = 15`)
+				require.Contains(tb, content, `input    = "{\"report\":\"daily\"}"`)
+
+				require.Contains(tb, content, `resource "aws_scheduler_schedule" "report_scheduler_schedule_2" {`)
+				require.Contains(tb, content, `schedule_expression = "at(2030-06-30T18:00:00)"`)
+				require.Contains(tb, content, `state               = var.year_end_report_enabled ? "ENABLED" : "DISABLED"`)
+				require.Contains(tb, content, `mode = "OFF"`)
+			},
+		},
+		{
+			name: "lambda settings should be rendered in both the resource and the module",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.function.yaml"),
+				output:         path.Join(testOutput, "function", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+
+				data, err := os.ReadFile(path.Join(modPath, "orderProcessor.tf"))
+				require.NoError(tb, err)
+
+				content := string(data)
+				require.Contains(tb, content, `handler       = "bootstrap"`)
+				require.Contains(tb, content, "memory_size                    = 512")
+				require.Contains(tb, content, "timeout                        = 30")
+				require.Contains(tb, content, `architectures                  = ["arm64"]`)
+				require.Contains(tb, content, "reserved_concurrent_executions = 5")
+				require.Contains(tb, content, `layers                         = `+
+					`["arn:aws:lambda:us-east-1:123456789012:layer:shared:1", aws_lambda_layer_version.tools.arn]`)
+				require.Contains(tb, content, "size = 1024")
+				require.Contains(tb, content, "target_arn = aws_sqs_queue.failed_orders_sqs.arn")
+				require.Contains(tb, content, `mode = "Active"`)
+				require.Contains(tb, content, "subnet_ids         = [var.private_subnet_id]")
+				require.Contains(tb, content, `security_group_ids = ["sg-0123456789abcdef0"]`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_log_group" "order_processor_lambda_log_group"`)
+				require.Contains(tb, content, "depends_on = [aws_cloudwatch_log_group.order_processor_lambda_log_group]")
+
+				iamData, err := os.ReadFile(path.Join(modPath, "orderProcessor-iam.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(iamData), `"ec2:CreateNetworkInterface"`)
+				require.Contains(tb, string(iamData), `"xray:PutTraceSegments"`)
+				require.Contains(tb, string(iamData), `Resource = [aws_sqs_queue.failed_orders_sqs.arn]`)
+
+				data, err = os.ReadFile(path.Join(modPath, "reportScheduler.tf"))
+				require.NoError(tb, err)
+
+				content = string(data)
+				require.Contains(tb, content, "lambda_function_memory_size              = 256")
+				require.Contains(tb, content, `lambda_function_tracing_mode             = "PassThrough"`)
+				require.Contains(tb, content, "lambda_function_log_retention_in_days    = 30")
+				require.Contains(tb, content, `subnet_ids         = ["subnet-0123456789abcdef0"]`)
+				require.NotContains(tb, content, "var.lambda_function_vpc_config")
+			},
+		},
+		{
+			name: "lambda code should follow its runtime",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.runtimes.yaml"),
+				output:         path.Join(testOutput, "runtimes", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				pythonPath := path.Join(output, "lambda", "orderProcessor")
+				require.NoFileExists(tb, path.Join(pythonPath, "main.go"))
+
+				pythonLambdaData, err := os.ReadFile(path.Join(pythonPath, "lambda_function.py"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(pythonLambdaData), "        process_message(record)\n")
+				require.NotContains(tb, string(pythonLambdaData), "batchItemFailures")
+
+				requirementsData, err := os.ReadFile(path.Join(pythonPath, "requirements.txt"))
+				require.NoError(tb, err)
+				require.Equal(tb, "requests==2.32.3", string(requirementsData))
+
+				pythonLambdaTfData, err := os.ReadFile(path.Join(output, "mod", "orderProcessor.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(pythonLambdaTfData), `handler       = "lambda_function.handler"`)
+
+				nodePath := path.Join(output, "lambda", "reportScheduler")
+				require.FileExists(tb, path.Join(nodePath, "index.js"))
+				require.FileExists(tb, path.Join(nodePath, "package.json"))
+				require.NoFileExists(tb, path.Join(nodePath, "requirements.txt"))
+
+				nodeLambdaTfData, err := os.ReadFile(path.Join(output, "mod", "reportScheduler.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(nodeLambdaTfData), `handler       = "index.handler"`)
+			},
+		},
 		{
 			name: "override default template for multiple lambda",
 			fields: fields{
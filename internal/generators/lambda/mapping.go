@@ -2,23 +2,53 @@ package lambda
 
 import (
 	_ "embed"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 )
 
 const (
-	filenameTfLambda = "lambda.tf"
-	filenameGoLambda = "lambda.go"
-	filenameGoMain   = "main.go"
+	filenameTfLambda       = "lambda.tf"
+	filenameGoConfig       = "config.go"
+	filenameGoDependencies = "dependencies.go"
+	filenameGoLambda       = "lambda.go"
+	filenameGoLambdaTest   = "lambda_test.go"
+	filenameGoMain         = "main.go"
+	filenamePyLambda       = "lambda_function.py"
+	filenamePyRequirements = "requirements.txt"
+	filenameJsIndex        = "index.js"
+	filenameJsPackage      = "package.json"
 )
 
 var (
 	//go:embed tmpls/lambda.tf.tmpl
 	lambdaTFTmpl []byte
 
+	//go:embed tmpls/config.go.tmpl
+	configGoTmpl []byte
+
+	//go:embed tmpls/dependencies.go.tmpl
+	dependenciesGoTmpl []byte
+
 	//go:embed tmpls/lambda.go.tmpl
 	lambdaGoTmpl []byte
 
+	//go:embed tmpls/lambda_test.go.tmpl
+	lambdaTestGoTmpl []byte
+
 	//go:embed tmpls/main.go.tmpl
 	mainGoTmpl []byte
+
+	//go:embed tmpls/lambda_function.py.tmpl
+	lambdaPyTmpl []byte
+
+	//go:embed tmpls/requirements.txt.tmpl
+	requirementsTxtTmpl []byte
+
+	//go:embed tmpls/index.js.tmpl
+	indexJsTmpl []byte
+
+	//go:embed tmpls/package.json.tmpl
+	packageJSONTmpl []byte
 )
 
 var (
@@ -26,8 +56,22 @@ var (
 		filenameTfLambda: string(lambdaTFTmpl),
 	}
 
-	defaultGoTemplatesMap = map[string]string{
-		filenameGoLambda: string(lambdaGoTmpl),
-		filenameGoMain:   string(mainGoTmpl),
+	// Code templates by runtime family.
+	defaultCodeTemplatesMap = map[string]map[string]string{
+		generators.RuntimeGo: {
+			filenameGoConfig:       string(configGoTmpl),
+			filenameGoDependencies: string(dependenciesGoTmpl),
+			filenameGoLambda:       string(lambdaGoTmpl),
+			filenameGoLambdaTest:   string(lambdaTestGoTmpl),
+			filenameGoMain:         string(mainGoTmpl),
+		},
+		generators.RuntimePython: {
+			filenamePyLambda:       string(lambdaPyTmpl),
+			filenamePyRequirements: string(requirementsTxtTmpl),
+		},
+		generators.RuntimeNodeJS: {
+			filenameJsIndex:   string(indexJsTmpl),
+			filenameJsPackage: string(packageJSONTmpl),
+		},
 	}
 )
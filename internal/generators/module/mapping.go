@@ -0,0 +1,46 @@
+package module
+
+import (
+	_ "embed"
+)
+
+const (
+	filenameVarsTf    = "vars.tf"
+	filenameOutputsTf = "outputs.tf"
+)
+
+var (
+	//go:embed tmpls/vars.tf.tmpl
+	tmplVarsTf []byte
+
+	//go:embed tmpls/outputs.tf.tmpl
+	tmplOutputsTf []byte
+)
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameVarsTf:    string(tmplVarsTf),
+	filenameOutputsTf: string(tmplOutputsTf),
+}
+
+// Types of the variables referenced by the default templates. Any other variable is a string, like the ones used by
+// the environment variables of the Lambdas.
+var variableTypes = map[string]string{
+	"api_http_error_alarm_period":  "number",
+	"api_latency_threshold_millis": "number",
+	"lambda_function_vpc_config":   "any",
+}
+
+// outputAttribute represents an attribute of a resource exposed by an output named after the resource and the suffix.
+type outputAttribute struct {
+	suffix    string
+	attribute string
+}
+
+// Attributes exposed by the outputs, by resource type.
+var outputAttributes = map[string][]outputAttribute{
+	"aws_apigatewayv2_api": {{"id", "id"}, {"arn", "arn"}, {"url", "api_endpoint"}, {"name", "name"}},
+	"aws_kinesis_stream":   {{"arn", "arn"}, {"name", "name"}},
+	"aws_lambda_function":  {{"arn", "arn"}, {"invoke_arn", "invoke_arn"}, {"name", "function_name"}},
+	"aws_s3_bucket":        {{"arn", "arn"}, {"name", "bucket"}},
+	"aws_sqs_queue":        {{"arn", "arn"}, {"url", "url"}, {"name", "name"}},
+}
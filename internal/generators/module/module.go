@@ -0,0 +1,231 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+const defaultVariableType = "string"
+
+type VariableData struct {
+	Name string
+	Type string
+}
+
+type OutputData struct {
+	Name  string
+	Value string
+}
+
+type Data struct {
+	Variables []VariableData
+	Outputs   []OutputData
+}
+
+// Module generates the variables and the outputs of the mod folders, from the Terraform files rendered into them.
+type Module struct {
+	configFileName string
+	output         string
+	stackName      string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
+}
+
+// NewModule returns a generator of the variables and outputs of the mod folders generated from the configuration file
+// into the output folder. The stack name is the folder of the resources that are not part of an API Gateway.
+func NewModule(configFileName, output, stackName string, opts ...generators.Option) *Module {
+	options := generators.NewOptions(opts...)
+
+	return &Module{
+		configFileName: configFileName,
+		output:         output,
+		stackName:      stackName,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
+}
+
+// Build scans the Terraform files of every mod folder and writes a vars.tf declaring each referenced var.* that is not
+// declared yet, and an outputs.tf exposing the queues, streams, buckets, functions and APIs.
+func (m *Module) Build() error {
+	yamlConfig, err := config.NewYAML(m.configFileName, m.yamlOptions...).Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Module))
+
+	tg := generators.NewGenerator()
+
+	var errs []error
+
+	for _, modPath := range m.modPaths(yamlConfig) {
+		data, err := m.buildData(modPath)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		var modErrs []error
+
+		if len(data.Variables) > 0 {
+			modErrs = append(modErrs, generators.GenerateFile(tg, m.fs, templates, filenameVarsTf, "",
+				path.Join(modPath, filenameVarsTf), data))
+		}
+
+		if len(data.Outputs) > 0 {
+			modErrs = append(modErrs, generators.GenerateFile(tg, m.fs, templates, filenameOutputsTf, "",
+				path.Join(modPath, filenameOutputsTf), data))
+		}
+
+		if err := errors.Join(modErrs...); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if len(data.Variables) > 0 || len(data.Outputs) > 0 {
+			fmtcolor.White.Printf("Module '%s' has been generated successfully\n", modPath)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// modPaths returns the mod folders, following the paths of the generators: the one of the stack, then the ones of the
+// API Gateways.
+func (m *Module) modPaths(yamlConfig *config.Config) []string {
+	modPaths := []string{path.Join(m.output, m.stackName, "mod")}
+
+	for i := range yamlConfig.APIGateways {
+		modPath := path.Join(m.output, yamlConfig.APIGateways[i].StackName, "mod")
+
+		if !slices.Contains(modPaths, modPath) {
+			modPaths = append(modPaths, modPath)
+		}
+	}
+
+	return modPaths
+}
+
+// buildData collects the variables referenced and the resources declared by the Terraform files of the folder. The
+// generated vars.tf and outputs.tf are not scanned, so the variables and outputs removed from the other files are
+// dropped.
+func (m *Module) buildData(modPath string) (Data, error) {
+	files, err := filesystem.ReadFiles(m.fs, modPath)
+	if err != nil {
+		return Data{}, fmt.Errorf("%w", err)
+	}
+
+	fileNames := make([]string, 0, len(files))
+
+	for fileName := range files {
+		if filepath.Ext(fileName) == ".tf" && fileName != filenameVarsTf && fileName != filenameOutputsTf {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+
+	sort.Strings(fileNames)
+
+	s := newScan()
+
+	for _, fileName := range fileNames {
+		file, diags := hclsyntax.ParseConfig(files[fileName], fileName, hcl.InitialPos)
+		if diags.HasErrors() {
+			fmtcolor.Yellow.Printf("File '%s' has been skipped: %s\n", path.Join(modPath, fileName), diags.Error())
+			continue
+		}
+
+		s.body(file.Body.(*hclsyntax.Body))
+	}
+
+	return s.data(), nil
+}
+
+// scan holds what the Terraform files of a folder reference and declare.
+type scan struct {
+	referenced map[string]struct{}
+	declared   map[string]struct{}
+	outputs    map[string]struct{}
+	resources  []OutputData
+}
+
+func newScan() *scan {
+	return &scan{referenced: map[string]struct{}{}, declared: map[string]struct{}{}, outputs: map[string]struct{}{}}
+}
+
+func (s *scan) body(body *hclsyntax.Body) {
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || expr.Traversal.RootName() != "var" || len(expr.Traversal) < 2 {
+			return nil
+		}
+
+		if attr, ok := expr.Traversal[1].(hcl.TraverseAttr); ok {
+			s.referenced[attr.Name] = struct{}{}
+		}
+
+		return nil
+	})
+
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "variable" && len(block.Labels) == 1:
+			s.declared[block.Labels[0]] = struct{}{}
+		case block.Type == "output" && len(block.Labels) == 1:
+			s.outputs[block.Labels[0]] = struct{}{}
+		case block.Type == "resource" && len(block.Labels) == 2:
+			for _, attr := range outputAttributes[block.Labels[0]] {
+				s.resources = append(s.resources, OutputData{
+					Name:  fmt.Sprintf("%s_%s", block.Labels[1], attr.suffix),
+					Value: fmt.Sprintf("%s.%s.%s", block.Labels[0], block.Labels[1], attr.attribute),
+				})
+			}
+		}
+	}
+}
+
+// data returns the variables that are referenced but not declared, and the outputs that are not declared, both sorted
+// by name.
+func (s *scan) data() Data {
+	var data Data
+
+	for name := range s.referenced {
+		if _, ok := s.declared[name]; ok {
+			continue
+		}
+
+		variableType, ok := variableTypes[name]
+		if !ok {
+			variableType = defaultVariableType
+		}
+
+		data.Variables = append(data.Variables, VariableData{Name: name, Type: variableType})
+	}
+
+	sort.Slice(data.Variables, func(i, j int) bool { return data.Variables[i].Name < data.Variables[j].Name })
+
+	for _, output := range s.resources {
+		if _, ok := s.outputs[output.Name]; !ok {
+			data.Outputs = append(data.Outputs, output)
+		}
+	}
+
+	sort.Slice(data.Outputs, func(i, j int) bool { return data.Outputs[i].Name < data.Outputs[j].Name })
+
+	return data
+}
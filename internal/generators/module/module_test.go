@@ -0,0 +1,177 @@
+package module
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+const (
+	sqsTf = `resource "aws_sqs_queue" "orders_sqs" {
+  name = "${var.client}-${var.environment}-orders"
+}
+`
+	lambdaTf = `resource "aws_lambda_function" "get_order_lambda" {
+  function_name = "get_order_lambda"
+
+  environment {
+    variables = {
+      QUEUE_URL = var.orders_queue_url
+      TIMEOUT   = var.timeout
+    }
+  }
+}
+
+variable "timeout" {
+  type = number
+}
+
+output "get_order_lambda_arn" {
+  value = aws_lambda_function.get_order_lambda.arn
+}
+`
+)
+
+func TestModule_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	memory := filesystem.NewMemory()
+	require.NoError(t, memory.WriteFile(path.Join(testOutput, "dryrun", "mystack", "mod", "sqs.tf"), []byte(sqsTf)))
+
+	tests := []struct {
+		name             string
+		fields           fields
+		files            map[string]string
+		fs               func() filesystem.FileSystem
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "variables and outputs of the stack and the api gateway mod folders",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "module.config.yaml"),
+				output:         path.Join(testOutput, "happypath"),
+			},
+			files: map[string]string{
+				path.Join("mystack", "mod", "sqs.tf"):         sqsTf,
+				path.Join("mystack", "mod", "vars.tf"):        `variable "removed" {}`,
+				path.Join("apistack", "mod", "getOrder.tf"):   lambdaTf,
+				path.Join("apistack", "mod", "README.md"):     "var.ignored",
+				path.Join("apistack", "mod", "invalid.tf"):    `resource "aws_sqs_queue" {`,
+				path.Join("apistack", "mod", "outputs.tf"):    `output "removed" {}`,
+				path.Join("apistack", "lambda", "other.tf"):   sqsTf,
+				path.Join("mystack", "mod", "dir.tf", "a.tf"): sqsTf,
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				stackVars := readFile(tb, path.Join(output, "mystack", "mod", "vars.tf"))
+				require.Equal(tb, "variable \"client\" {\n  type = string\n}\n\n"+
+					"variable \"environment\" {\n  type = string\n}\n", stackVars)
+
+				stackOutputs := readFile(tb, path.Join(output, "mystack", "mod", "outputs.tf"))
+				require.Contains(tb, stackOutputs, "output \"orders_sqs_url\" {\n  value = aws_sqs_queue.orders_sqs.url\n}")
+				require.Contains(tb, stackOutputs, `output "orders_sqs_arn"`)
+				require.Contains(tb, stackOutputs, `output "orders_sqs_name"`)
+
+				apiVars := readFile(tb, path.Join(output, "apistack", "mod", "vars.tf"))
+				require.Equal(tb, "variable \"orders_queue_url\" {\n  type = string\n}\n", apiVars)
+
+				apiOutputs := readFile(tb, path.Join(output, "apistack", "mod", "outputs.tf"))
+				require.NotContains(tb, apiOutputs, `output "get_order_lambda_arn"`)
+				require.NotContains(tb, apiOutputs, `output "removed"`)
+				require.Contains(tb, apiOutputs, `value = aws_lambda_function.get_order_lambda.function_name`)
+			},
+		},
+		{
+			name: "dry run should scan the files kept in memory",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "module.config.yaml"),
+				output:         path.Join(testOutput, "dryrun"),
+			},
+			fs: func() filesystem.FileSystem { return memory },
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				require.NoDirExists(tb, output)
+
+				changes, err := memory.Changes()
+				require.NoError(tb, err)
+
+				paths := make([]string, 0, len(changes))
+				for _, change := range changes {
+					paths = append(paths, change.Path)
+				}
+
+				modPath := path.Join(output, "mystack", "mod")
+				require.Equal(tb, []string{
+					path.Join(modPath, "sqs.tf"), path.Join(modPath, "vars.tf"), path.Join(modPath, "outputs.tf"),
+				}, paths)
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			for name, content := range tc.files {
+				fileName := path.Join(tc.fields.output, name)
+				require.NoError(t, os.MkdirAll(path.Dir(fileName), os.ModePerm))
+				require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+			}
+
+			var opts []generators.Option
+			if tc.fs != nil {
+				opts = append(opts, generators.WithFileSystem(tc.fs()))
+			}
+
+			err := NewModule(tc.fields.configFileName, tc.fields.output, "mystack", opts...).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
+
+func readFile(tb testing.TB, name string) string {
+	tb.Helper()
+
+	content, err := os.ReadFile(name)
+	require.NoError(tb, err)
+
+	return string(content)
+}
@@ -0,0 +1,40 @@
+package generators
+
+import (
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// Options holds the settings shared by every generator.
+type Options struct {
+	FileSystem  filesystem.FileSystem
+	YAMLOptions []config.YAMLOption
+}
+
+// Option is a functional option to configure the generators.
+type Option func(*Options)
+
+// WithFileSystem sets the file system used to write the generated files.
+func WithFileSystem(fs filesystem.FileSystem) Option {
+	return func(o *Options) {
+		o.FileSystem = fs
+	}
+}
+
+// WithYAMLOptions sets the options used to load the configuration file.
+func WithYAMLOptions(opts ...config.YAMLOption) Option {
+	return func(o *Options) {
+		o.YAMLOptions = append(o.YAMLOptions, opts...)
+	}
+}
+
+// NewOptions returns the options of a generator. By default, the files are written to disk.
+func NewOptions(opts ...Option) Options {
+	o := Options{FileSystem: filesystem.NewOS()}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
@@ -0,0 +1,117 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+)
+
+// ErrUnknownStep represents a step name that is not part of the pipeline.
+var ErrUnknownStep = errors.New("unknown generator")
+
+// Status represents the outcome of a pipeline step.
+type Status string
+
+const (
+	StatusGenerated Status = "generated"
+	StatusSkipped   Status = "skipped"
+	StatusFailed    Status = "failed"
+)
+
+// Builder is implemented by every generator.
+type Builder interface {
+	Build() error
+}
+
+// Step represents a generator in the pipeline. Skip indicates that the config does not declare anything for the step.
+type Step struct {
+	Name    string
+	Builder Builder
+	Skip    bool
+}
+
+// Result represents the outcome of a step once the pipeline has run. Skipped holds the files that have not been
+// overwritten to keep their hand edits, which does not make the step fail.
+type Result struct {
+	Name    string
+	Status  Status
+	Err     error
+	Skipped []error
+}
+
+// Pipeline runs a list of generators in order.
+type Pipeline struct {
+	steps []Step
+}
+
+func NewPipeline(steps ...Step) *Pipeline {
+	return &Pipeline{steps: steps}
+}
+
+// Run builds every step in order. When only is not empty, the steps that are not listed are skipped. A failing step
+// does not stop the pipeline: all errors are joined and returned along with the result of each step. The files skipped
+// to keep their hand edits are reported in the results, not as errors.
+func (p *Pipeline) Run(only []string) ([]Result, error) {
+	selected, err := p.selectSteps(only)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(p.steps))
+
+	var errs []error
+
+	for _, step := range p.steps {
+		if _, ok := selected[step.Name]; (len(selected) > 0 && !ok) || step.Skip {
+			results = append(results, Result{Name: step.Name, Status: StatusSkipped})
+
+			continue
+		}
+
+		skipped, err := filesystem.SplitSkipped(step.Builder.Build())
+		if err != nil {
+			results = append(results, Result{Name: step.Name, Status: StatusFailed, Err: err, Skipped: skipped})
+			errs = append(errs, fmt.Errorf("%s: %w", step.Name, err))
+
+			continue
+		}
+
+		results = append(results, Result{Name: step.Name, Status: StatusGenerated, Skipped: skipped})
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (p *Pipeline) selectSteps(only []string) (map[string]struct{}, error) {
+	names := map[string]struct{}{}
+	for _, step := range p.steps {
+		names[step.Name] = struct{}{}
+	}
+
+	selected := map[string]struct{}{}
+
+	var unknown []string
+
+	for _, name := range only {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if _, ok := names[name]; !ok {
+			unknown = append(unknown, name)
+
+			continue
+		}
+
+		selected[name] = struct{}{}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStep, strings.Join(unknown, ", "))
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+)
+
+var (
+	errDummy   = errors.New("dummy error")
+	errSkipped = fmt.Errorf("%w: sqs.tf", filesystem.ErrSkipped)
+)
+
+type builderFunc func() error
+
+func (f builderFunc) Build() error { return f() }
+
+func TestPipeline_Run(t *testing.T) {
+	type args struct {
+		only []string
+	}
+
+	tests := []struct {
+		name      string
+		steps     func(calls *[]string) []Step
+		args      args
+		want      []Result
+		targetErr error
+	}{
+		{
+			name: "run every step in order",
+			steps: func(calls *[]string) []Step {
+				return []Step{
+					{Name: "lambda", Builder: recordBuilder(calls, "lambda", nil)},
+					{Name: "sqs", Builder: recordBuilder(calls, "sqs", nil)},
+				}
+			},
+			want: []Result{{Name: "lambda", Status: StatusGenerated}, {Name: "sqs", Status: StatusGenerated}},
+		},
+		{
+			name: "skip steps without resources and steps that are not selected",
+			steps: func(calls *[]string) []Step {
+				return []Step{
+					{Name: "kinesis", Builder: recordBuilder(calls, "kinesis", nil)},
+					{Name: "lambda", Builder: recordBuilder(calls, "lambda", nil)},
+					{Name: "sqs", Builder: recordBuilder(calls, "sqs", nil), Skip: true},
+				}
+			},
+			args: args{only: []string{" Lambda", "sqs", ""}},
+			want: []Result{
+				{Name: "kinesis", Status: StatusSkipped},
+				{Name: "lambda", Status: StatusGenerated},
+				{Name: "sqs", Status: StatusSkipped},
+			},
+		},
+		{
+			name: "keep running after a failure and aggregate the errors",
+			steps: func(calls *[]string) []Step {
+				return []Step{
+					{Name: "lambda", Builder: recordBuilder(calls, "lambda", errDummy)},
+					{Name: "sqs", Builder: recordBuilder(calls, "sqs", nil)},
+				}
+			},
+			want: []Result{
+				{Name: "lambda", Status: StatusFailed, Err: errDummy},
+				{Name: "sqs", Status: StatusGenerated},
+			},
+			targetErr: errDummy,
+		},
+		{
+			name: "report the files skipped to keep their hand edits without failing",
+			steps: func(calls *[]string) []Step {
+				return []Step{
+					{Name: "lambda", Builder: recordBuilder(calls, "lambda", errors.Join(errSkipped, errDummy))},
+					{Name: "sqs", Builder: recordBuilder(calls, "sqs", errSkipped)},
+				}
+			},
+			want: []Result{
+				{Name: "lambda", Status: StatusFailed, Err: errors.Join(errDummy), Skipped: []error{errSkipped}},
+				{Name: "sqs", Status: StatusGenerated, Skipped: []error{errSkipped}},
+			},
+			targetErr: errDummy,
+		},
+		{
+			name: "unknown step",
+			steps: func(calls *[]string) []Step {
+				return []Step{{Name: "lambda", Builder: recordBuilder(calls, "lambda", nil)}}
+			},
+			args:      args{only: []string{"lambda", "unknown"}},
+			targetErr: ErrUnknownStep,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			calls := []string{}
+
+			got, err := NewPipeline(tc.steps(&calls)...).Run(tc.args.only)
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+
+			var wantCalls []string
+
+			for _, result := range tc.want {
+				if result.Status != StatusSkipped {
+					wantCalls = append(wantCalls, result.Name)
+				}
+			}
+
+			require.ElementsMatch(t, wantCalls, calls)
+		})
+	}
+}
+
+func recordBuilder(calls *[]string, name string, err error) Builder {
+	return builderFunc(func() error {
+		*calls = append(*calls, name)
+
+		return err
+	})
+}
@@ -0,0 +1,85 @@
+package generators
+
+import (
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+const (
+	// RuntimeGo is the family of the Go runtimes, which is also used for the custom runtimes and when no runtime is
+	// set.
+	RuntimeGo = "go"
+
+	// RuntimePython is the family of the Python runtimes, like python3.12.
+	RuntimePython = "python"
+
+	// RuntimeNodeJS is the family of the Node.js runtimes, like nodejs20.x.
+	RuntimeNodeJS = "nodejs"
+
+	// runtimeCustomPrefix is the prefix of the custom runtimes, like provided.al2023.
+	runtimeCustomPrefix = "provided"
+)
+
+const (
+	// HandlerPython is the handler of the Python Lambdas: the handler function of the lambda_function.py file.
+	HandlerPython = "lambda_function.handler"
+
+	// HandlerNodeJS is the handler of the Node.js Lambdas: the handler function exported by the index.js file.
+	HandlerNodeJS = "index.handler"
+
+	// HandlerCustomRuntime is the handler of the Lambdas with a custom runtime: the bootstrap binary their Go code is
+	// built as.
+	HandlerCustomRuntime = "bootstrap"
+)
+
+// Filters of the file names generated for each runtime family.
+var runtimeFileFilters = map[string][]string{
+	RuntimeGo:     {".go"},
+	RuntimePython: {".py", "requirements.txt"},
+	RuntimeNodeJS: {".js", "package.json"},
+}
+
+// RuntimeFamily returns the family of the Lambda runtime identifier.
+func RuntimeFamily(runtime string) string {
+	switch {
+	case strings.HasPrefix(runtime, RuntimePython):
+		return RuntimePython
+	case strings.HasPrefix(runtime, RuntimeNodeJS):
+		return RuntimeNodeJS
+	default:
+		return RuntimeGo
+	}
+}
+
+// IsCustomRuntime returns true when the Lambda runtime identifier is a custom runtime, like provided.al2023.
+func IsCustomRuntime(runtime string) bool {
+	return strings.HasPrefix(runtime, runtimeCustomPrefix)
+}
+
+// RuntimeHandler returns the handler attribute of a Lambda with the given runtime. The Go Lambdas are built as a
+// binary, so their handler is the given Go handler, or bootstrap for the custom runtimes.
+func RuntimeHandler(runtime, goHandler string) string {
+	switch {
+	case RuntimeFamily(runtime) == RuntimePython:
+		return HandlerPython
+	case RuntimeFamily(runtime) == RuntimeNodeJS:
+		return HandlerNodeJS
+	case IsCustomRuntime(runtime):
+		return HandlerCustomRuntime
+	default:
+		return goHandler
+	}
+}
+
+// FilterRuntimeTemplatesMap filters a map of filenames to templates, keeping the files generated for the runtime
+// family.
+func FilterRuntimeTemplatesMap(family string, templatesMap map[string]string) map[string]string {
+	filtered := map[string]string{}
+
+	for _, filter := range runtimeFileFilters[family] {
+		filtered = utils.MergeStringMap(filtered, FilterTemplatesMap(filter, templatesMap))
+	}
+
+	return filtered
+}
@@ -0,0 +1,83 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeFamily(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		want    string
+	}{
+		{name: "go", runtime: "go1.x", want: RuntimeGo},
+		{name: "custom runtime", runtime: "provided.al2023", want: RuntimeGo},
+		{name: "empty runtime", runtime: "", want: RuntimeGo},
+		{name: "python", runtime: "python3.12", want: RuntimePython},
+		{name: "nodejs", runtime: "nodejs20.x", want: RuntimeNodeJS},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, RuntimeFamily(tc.runtime))
+		})
+	}
+}
+
+func TestRuntimeHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		runtime string
+		want    string
+	}{
+		{name: "go", runtime: "go1.x", want: "my_lambda"},
+		{name: "custom runtime", runtime: "provided.al2023", want: HandlerCustomRuntime},
+		{name: "python", runtime: "python3.12", want: HandlerPython},
+		{name: "nodejs", runtime: "nodejs20.x", want: HandlerNodeJS},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, RuntimeHandler(tc.runtime, "my_lambda"))
+		})
+	}
+}
+
+func TestFilterRuntimeTemplatesMap(t *testing.T) {
+	templatesMap := map[string]string{
+		"lambda.go":          "go",
+		"lambda.tf":          "tf",
+		"lambda_function.py": "py",
+		"requirements.txt":   "txt",
+		"index.js":           "js",
+		"package.json":       "json",
+	}
+
+	tests := []struct {
+		name   string
+		family string
+		want   map[string]string
+	}{
+		{name: "go", family: RuntimeGo, want: map[string]string{"lambda.go": "go"}},
+		{
+			name:   "python",
+			family: RuntimePython,
+			want:   map[string]string{"lambda_function.py": "py", "requirements.txt": "txt"},
+		},
+		{name: "nodejs", family: RuntimeNodeJS, want: map[string]string{"index.js": "js", "package.json": "json"}},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, FilterRuntimeTemplatesMap(tc.family, templatesMap))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package s3
 
 import (
 	_ "embed"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 )
 
 const filenameS3tf = "s3.tf"
@@ -9,6 +11,16 @@ const filenameS3tf = "s3.tf"
 //go:embed tmpls/s3.tf.tmpl
 var tmplS3tf []byte
 
-var defaultTfTemplateFiles = map[string]string{
-	filenameS3tf: string(tmplS3tf),
+//go:embed tmpls/s3.v5.tf.tmpl
+var tmplS3V5tf []byte
+
+// Default templates by major version of the AWS provider. Since the version 4, the settings of a bucket are managed
+// by their own resources.
+var defaultTfTemplateFiles = map[int]map[string]string{
+	config.AWSProviderV3: {
+		filenameS3tf: string(tmplS3tf),
+	},
+	config.AWSProviderV5: {
+		filenameS3tf: string(tmplS3V5tf),
+	},
 }
@@ -2,11 +2,13 @@ package s3
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -17,19 +19,29 @@ import (
 type Data struct {
 	Name           string
 	ExpirationDays int
+	Tags           map[string]string
 }
 
 type S3 struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewS3(configFileName, output string) *S3 {
-	return &S3{configFileName: configFileName, output: output}
+func NewS3(configFileName, output string, opts ...generators.Option) *S3 {
+	options := generators.NewOptions(opts...)
+
+	return &S3{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (s *S3) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
+	yamlParser := config.NewYAML(s.configFileName, s.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -37,27 +49,32 @@ func (s *S3) Build() error {
 	}
 
 	modPath := path.Join(s.output, "mod")
-	_ = os.MkdirAll(modPath, os.ModePerm)
 
 	result := make([]string, 0, len(yamlConfig.Buckets))
 
-	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles[yamlConfig.GetAWSProviderVersion()]),
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.S3Bucket))
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.Buckets {
 		conf := yamlConfig.Buckets[i]
 
 		data := Data{
 			Name:           conf.Name,
 			ExpirationDays: conf.ExpirationDays,
+			Tags:           yamlConfig.ResourceTags(conf.Tags),
 		}
 
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
-			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+			if err := generators.GenerateFiles(tg, s.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
 			fmtcolor.White.Printf("S3 '%s' has been generated successfully\n", conf.Name)
 
@@ -75,10 +92,13 @@ func (s *S3) Build() error {
 	if len(result) > 0 {
 		outputFile := path.Join(modPath, filenameS3tf)
 
-		generators.MustGenerateFile(tg, nil, filenameS3tf, strings.Join(result, "\n"), outputFile, Data{})
+		err := generators.GenerateFile(tg, s.fs, nil, filenameS3tf, strings.Join(result, "\n"), outputFile, Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
 
 		fmtcolor.White.Println("S3 has been generated successfully")
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
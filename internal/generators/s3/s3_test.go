@@ -56,6 +56,26 @@ func TestS3_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(output, "mod", "s3.tf"))
 			},
 		},
+		{
+			name: "aws provider v5 should manage the bucket settings with their own resources",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "s3.config.v5.yaml"),
+				output:         path.Join(testOutput, "v5"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				content, err := os.ReadFile(path.Join(output, "mod", "s3.tf"))
+				require.NoError(tb, err)
+				require.NotContains(tb, string(content), "aws_s3_bucket_acl")
+				require.Contains(tb, string(content), `resource "aws_s3_bucket_ownership_controls" "my_first_bucket_ownership"`)
+				require.Contains(tb, string(content), `"aws_s3_bucket_public_access_block" "my_first_bucket_public_access"`)
+				require.Contains(tb, string(content), `"aws_s3_bucket_server_side_encryption_configuration"`)
+				require.Contains(tb, string(content), `resource "aws_s3_bucket_versioning" "my_second_bucket_versioning"`)
+			},
+		},
 		{
 			name: "at least one s3 customising",
 			fields: fields{
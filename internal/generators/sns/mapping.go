@@ -4,11 +4,20 @@ import (
 	_ "embed"
 )
 
-const filenameSNStf = "sns.tf"
+const (
+	filenameSNStf      = "sns.tf"
+	filenameSNSTopictf = "sns-topic.tf"
+)
+
+var (
+	//go:embed tmpls/sns.tf.tmpl
+	tmplSNStf []byte
 
-//go:embed tmpls/sns.tf.tmpl
-var tmplSNStf []byte
+	//go:embed tmpls/sns-topic.tf.tmpl
+	tmplSNSTopictf []byte
+)
 
 var defaultTfTemplateFiles = map[string]string{
-	filenameSNStf: string(tmplSNStf),
+	filenameSNStf:      string(tmplSNStf),
+	filenameSNSTopictf: string(tmplSNSTopictf),
 }
@@ -2,23 +2,37 @@ package sns
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/eventbridge"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
+const defaultBucketEvent = "s3:ObjectCreated:*"
+
 type Data struct {
-	Name       string
-	BucketName string
-	Lambdas    []ResourceData
-	SQSs       []ResourceData
+	Name            string
+	BucketName      string
+	BucketEvents    string
+	FIFO            bool
+	KMSMasterKeyID  string
+	Subscriptions   []SubscriptionData
+	EventBridgeARNs []string
+	Lambdas         []ResourceData
+	SQSs            []ResourceData
+	Tags            map[string]string
 }
 
 type ResourceData struct {
@@ -28,17 +42,35 @@ type ResourceData struct {
 	FilterSuffix string
 }
 
+type SubscriptionData struct {
+	Label              string
+	Protocol           string
+	Endpoint           string
+	FilterPolicy       string
+	FilterPolicyScope  string
+	RawMessageDelivery bool
+}
+
 type SNS struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewSNS(configFileName, output string) *SNS {
-	return &SNS{configFileName: configFileName, output: output}
+func NewSNS(configFileName, output string, opts ...generators.Option) *SNS {
+	options := generators.NewOptions(opts...)
+
+	return &SNS{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (s *SNS) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
+	yamlParser := config.NewYAML(s.configFileName, s.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -46,37 +78,52 @@ func (s *SNS) Build() error {
 	}
 
 	modPath := path.Join(s.output, "mod")
-	_ = os.MkdirAll(modPath, os.ModePerm)
 
 	result := make([]string, 0, len(yamlConfig.SNSs))
 
-	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.SNS))
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.SNSs {
 		conf := yamlConfig.SNSs[i]
 
 		data := Data{
-			Name:       conf.Name,
-			BucketName: conf.BucketName,
+			Name:           conf.Name,
+			BucketName:     conf.BucketName,
+			BucketEvents:   buildBucketEvents(&conf),
+			FIFO:           conf.FIFO,
+			KMSMasterKeyID: conf.KMSMasterKeyID,
+			Tags:           yamlConfig.ResourceTags(conf.Tags),
 		}
 
+		data.Subscriptions = buildSubscriptions(&conf)
+		data.EventBridgeARNs = buildEventBridgeARNs(&conf, yamlConfig.EventBridges)
 		data.Lambdas = buildLambdaResources(&conf)
 		data.SQSs = buildSQSResources(&conf)
 
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
-			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+			if err := generators.GenerateFiles(tg, s.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
 			fmtcolor.White.Printf("SNS '%s' has been generated successfully\n", conf.Name)
 
 			continue
 		}
 
-		output, err := tg.Build(data, "sns-tf-template", templates[filenameSNStf])
+		templateName, templateFile := "sns-topic-tf-template", filenameSNSTopictf
+		if conf.GetMode() == config.SNSModeBucketNotification {
+			templateName, templateFile = "sns-tf-template", filenameSNStf
+		}
+
+		output, err := tg.Build(data, templateName, templates[templateFile])
 		if err != nil {
 			return fmt.Errorf("%w", err)
 		}
@@ -87,12 +134,42 @@ func (s *SNS) Build() error {
 	if len(result) > 0 {
 		outputFile := path.Join(modPath, filenameSNStf)
 
-		generators.MustGenerateFile(tg, nil, filenameSNStf, strings.Join(result, "\n"), outputFile, Data{})
+		err := generators.GenerateFile(tg, s.fs, nil, filenameSNStf, strings.Join(result, "\n"), outputFile, Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
 
 		fmtcolor.White.Println("SNS has been generated successfully")
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// buildEventBridgeARNs returns the ARNs of the EventBridge rules targeting the topic, which are allowed to publish.
+func buildEventBridgeARNs(conf *config.SNS, eventBridges []config.EventBridge) []string {
+	topicLabel := strcase.ToSnake(conf.Name) + "_sns"
+
+	var arns []string
+
+	for i := range eventBridges {
+		for _, rule := range eventBridges[i].Rules {
+			for j := range rule.Targets {
+				target := rule.Targets[j]
+				if target.Type != config.EventBridgeTargetSNS {
+					continue
+				}
+
+				if label, _ := eventbridge.TargetLabel(&target); label == topicLabel {
+					arns = append(arns, fmt.Sprintf("%s.%s.arn", awsresources.LabelAWSCron,
+						eventbridge.RuleLabel(eventBridges[i].Name, rule.Name)))
+
+					break
+				}
+			}
+		}
+	}
+
+	return arns
 }
 
 func buildLambdaResources(conf *config.SNS) []ResourceData {
@@ -122,3 +199,68 @@ func buildSQSResources(conf *config.SNS) []ResourceData {
 
 	return sqsEvents
 }
+
+func buildBucketEvents(conf *config.SNS) string {
+	events := conf.BucketEvents
+	if len(events) == 0 {
+		events = []string{defaultBucketEvent}
+	}
+
+	quoted := make([]string, 0, len(events))
+	for _, event := range events {
+		quoted = append(quoted, fmt.Sprintf("%q", event))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func buildSubscriptions(conf *config.SNS) []SubscriptionData {
+	subscriptions := make([]SubscriptionData, 0, len(conf.Subscriptions))
+	countByProtocol := map[string]int{}
+
+	for _, sub := range conf.Subscriptions {
+		protocol := strings.ToLower(sub.Protocol)
+
+		var label, endpoint string
+
+		switch protocol {
+		case "sqs":
+			label = resourceLabel(sub.Endpoint, awsresources.SQSType, "_sqs")
+			endpoint = fmt.Sprintf("%s.%s.arn", awsresources.LabelAWSSQSQueue, label)
+		case "lambda":
+			label = resourceLabel(sub.Endpoint, awsresources.LambdaType, "_lambda")
+			endpoint = fmt.Sprintf("%s.%s.arn", awsresources.LabelAWSLambdaFunction, label)
+		default:
+			countByProtocol[protocol]++
+			label = fmt.Sprintf("%s_%d", strcase.ToSnake(protocol), countByProtocol[protocol])
+			endpoint = generators.QuoteIfLiteral(sub.Endpoint)
+		}
+
+		var filterPolicy string
+		if sub.FilterPolicy != "" {
+			filterPolicy = fmt.Sprintf("%q", sub.FilterPolicy)
+		}
+
+		subscriptions = append(subscriptions, SubscriptionData{
+			Label:              label,
+			Protocol:           protocol,
+			Endpoint:           endpoint,
+			FilterPolicy:       filterPolicy,
+			FilterPolicyScope:  sub.FilterPolicyScope,
+			RawMessageDelivery: sub.RawMessageDelivery,
+		})
+	}
+
+	return subscriptions
+}
+
+// resourceLabel returns the terraform label of the subscribed resource. The endpoint can be either the resource name
+// or a terraform reference such as aws_sqs_queue.my_queue_sqs.arn.
+func resourceLabel(endpoint string, resType awsresources.ResourceType, suffix string) string {
+	arn := awsresources.ParseResourceARN(endpoint, resType)
+	if arn.Label != "" {
+		return arn.Label
+	}
+
+	return strcase.ToSnake(arn.Name) + suffix
+}
@@ -89,6 +89,57 @@ func TestSNS_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(modPath, "with-sqs-sns.tf"))
 			},
 		},
+		{
+			name: "topics with subscriptions",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sns.config.topic.yaml"),
+				output:         path.Join(testOutput, "topic"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				snsTf := path.Join(output, "mod", "sns.tf")
+				require.FileExists(tb, snsTf)
+
+				snsTfData, err := os.ReadFile(snsTf)
+				require.NoError(tb, err)
+
+				content := string(snsTfData)
+				require.Contains(tb, content, `resource "aws_sns_topic" "order_events_sns"`)
+				require.Contains(tb, content, `resource "aws_sns_topic_policy" "order_events_sns_policy"`)
+				require.Contains(tb, content, `resource "aws_s3_bucket_notification" "s3_bucket_notification_order_events"`)
+				require.Contains(tb, content, `resource "aws_sns_topic_subscription" "order_events_sns_to_target_sqs"`)
+				require.Contains(tb, content, `resource "aws_sqs_queue_policy" "target_sqs_from_order_events_sns_policy"`)
+				require.Contains(tb, content, `resource "aws_sns_topic_subscription" "order_events_sns_to_example_receiver_lambda"`)
+				require.Contains(tb, content, `resource "aws_lambda_permission" "example_receiver_lambda_allow_order_events_sns"`)
+				require.Contains(tb, content, `endpoint  = "https://example.com/hooks/orders"`)
+				require.Contains(tb, content, `endpoint  = "team@example.com"`)
+				require.Contains(tb, content, `filter_policy = "{\"event_type\":[\"order_created\"]}"`)
+				require.Contains(tb, content, `name = "${var.client}-${var.environment}-order-events-fifo.fifo"`)
+				require.Contains(tb, content, `endpoint  = aws_sqs_queue.target_fifo_sqs.arn`)
+			},
+		},
+		{
+			name: "topic targeted by an EventBridge rule",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sns.config.eventbridge.yaml"),
+				output:         path.Join(testOutput, "eventbridge"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				snsTfData, err := os.ReadFile(path.Join(output, "mod", "sns.tf"))
+				require.NoError(tb, err)
+
+				content := string(snsTfData)
+				require.Contains(tb, content, `Sid    = "AllowEventBridgePublish"`)
+				require.Contains(tb, content, `aws_cloudwatch_event_rule.orders_order_created_rule.arn,`)
+			},
+		},
 		{
 			name: "when yaml parser fails should return an error",
 			fields: fields{
@@ -2,11 +2,13 @@ package sqs
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
 	"path"
 	"strings"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -14,22 +16,48 @@ import (
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
+const defaultVisibilityTimeoutSeconds = 720
+
+type DLQData struct {
+	Enabled                 bool
+	MessageRetentionSeconds int
+	RedrivePermission       string
+}
+
 type Data struct {
-	Name            string
-	MaxReceiveCount int32
+	Name                     string
+	MaxReceiveCount          int32
+	FIFO                     bool
+	VisibilityTimeoutSeconds int
+	MessageRetentionSeconds  int
+	DelaySeconds             int
+	ReceiveWaitTimeSeconds   int
+	SQSManagedSSE            bool
+	KMSMasterKeyID           string
+	DLQ                      DLQData
+	Tags                     map[string]string
 }
 
 type SQS struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewSQS(configFileName, output string) *SQS {
-	return &SQS{configFileName: configFileName, output: output}
+func NewSQS(configFileName, output string, opts ...generators.Option) *SQS {
+	options := generators.NewOptions(opts...)
+
+	return &SQS{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (s *SQS) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
+	yamlParser := config.NewYAML(s.configFileName, s.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -37,27 +65,29 @@ func (s *SQS) Build() error {
 	}
 
 	modPath := path.Join(s.output, "mod")
-	_ = os.MkdirAll(modPath, os.ModePerm)
 
 	result := make([]string, 0, len(yamlConfig.SQSs))
 
-	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+	templates := utils.MergeStringMap(maps.Clone(defaultTfTemplateFiles),
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.SQS))
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.SQSs {
 		conf := yamlConfig.SQSs[i]
 
-		data := Data{
-			Name:            conf.Name,
-			MaxReceiveCount: conf.MaxReceiveCount,
-		}
+		data := buildData(&conf)
+		data.Tags = yamlConfig.ResourceTags(conf.Tags)
 
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
-			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+			if err := generators.GenerateFiles(tg, s.fs, nil, filesConf, data, modPath); err != nil {
+				errs = append(errs, err)
+				continue
+			}
 
 			fmtcolor.White.Printf("SQS '%s' has been generated successfully\n", conf.Name)
 
@@ -75,10 +105,37 @@ func (s *SQS) Build() error {
 	if len(result) > 0 {
 		outputFile := path.Join(modPath, filenameSQStf)
 
-		generators.MustGenerateFile(tg, nil, filenameSQStf, strings.Join(result, "\n"), outputFile, Data{})
+		err := generators.GenerateFile(tg, s.fs, nil, filenameSQStf, strings.Join(result, "\n"), outputFile, Data{})
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
 
 		fmtcolor.White.Println("SQS has been generated successfully")
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+func buildData(conf *config.SQS) Data {
+	visibilityTimeoutSeconds := conf.VisibilityTimeoutSeconds
+	if visibilityTimeoutSeconds <= 0 {
+		visibilityTimeoutSeconds = defaultVisibilityTimeoutSeconds
+	}
+
+	return Data{
+		Name:                     conf.Name,
+		MaxReceiveCount:          conf.MaxReceiveCount,
+		FIFO:                     conf.FIFO,
+		VisibilityTimeoutSeconds: visibilityTimeoutSeconds,
+		MessageRetentionSeconds:  conf.MessageRetentionSeconds,
+		DelaySeconds:             conf.DelaySeconds,
+		ReceiveWaitTimeSeconds:   conf.ReceiveWaitTimeSeconds,
+		SQSManagedSSE:            conf.SQSManagedSSE && conf.KMSMasterKeyID == "",
+		KMSMasterKeyID:           conf.KMSMasterKeyID,
+		DLQ: DLQData{
+			Enabled:                 !conf.DLQ.Disabled,
+			MessageRetentionSeconds: conf.DLQ.MessageRetentionSeconds,
+			RedrivePermission:       conf.DLQ.RedrivePermission,
+		},
+	}
 }
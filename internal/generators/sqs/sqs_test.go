@@ -4,6 +4,7 @@ import (
 	_ "embed"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
@@ -56,6 +57,57 @@ func TestSQS_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(output, "mod", "sqs.tf"))
 			},
 		},
+		{
+			name: "tags of the resource should override the tags of the root",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				content, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+				require.Equal(tb, 2, strings.Count(string(content), `"team"        = "orders"`))
+				require.Equal(tb, 2, strings.Count(string(content), `"cost-center" = "42"`))
+				require.NotContains(tb, string(content), "payments")
+				require.Equal(tb, 2, strings.Count(string(content), `"note"        = "say \"hi\" to $${var.team}"`))
+			},
+		},
+		{
+			name: "fifo, encryption, timing and dlq options",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.options.yaml"),
+				output:         path.Join(testOutput, "options"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				data, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(data)
+				require.Contains(tb, content, `name                       = "${var.client}-${var.environment}-orders.fifo"`)
+				require.Contains(tb, content, `name                       = "${var.client}-${var.environment}-orders-dlq.fifo"`)
+				require.Contains(tb, content, `visibility_timeout_seconds = 60`)
+				require.Contains(tb, content, `message_retention_seconds  = 86400`)
+				require.Contains(tb, content, `delay_seconds              = 10`)
+				require.Contains(tb, content, `receive_wait_time_seconds  = 20`)
+				require.Contains(tb, content, `content_based_deduplication = true`)
+				require.Equal(tb, 2, strings.Count(content, `kms_master_key_id = var.sqs_kms_key_id`))
+				require.Contains(tb, content, `redrivePermission = "byQueue"`)
+				require.Contains(tb, content,
+					`sourceQueueArns   = ["arn:aws:sqs:${var.region}:${var.account_id}:${var.client}-${var.environment}-orders.fifo"]`)
+
+				require.Contains(tb, content, `resource "aws_sqs_queue" "events_sqs"`)
+				require.Contains(tb, content, `sqs_managed_sse_enabled = true`)
+				require.NotContains(tb, content, `events_sqs_dlq`)
+			},
+		},
 		{
 			name: "at least one sqs customising",
 			fields: fields{
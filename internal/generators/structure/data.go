@@ -1,5 +1,7 @@
 package structure
 
 type Data struct {
-	StackName string
+	StackName          string
+	AWSProviderVersion string
+	DefaultTags        map[string]string
 }
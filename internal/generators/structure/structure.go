@@ -2,10 +2,11 @@ package structure
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
-	"os"
 	"path"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -15,14 +16,23 @@ import (
 type Structure struct {
 	configFileName string
 	output         string
+	fs             filesystem.FileSystem
+	yamlOptions    []config.YAMLOption
 }
 
-func NewStructure(configFileName, output string) *Structure {
-	return &Structure{configFileName: configFileName, output: output}
+func NewStructure(configFileName, output string, opts ...generators.Option) *Structure {
+	options := generators.NewOptions(opts...)
+
+	return &Structure{
+		configFileName: configFileName,
+		output:         output,
+		fs:             options.FileSystem,
+		yamlOptions:    options.YAMLOptions,
+	}
 }
 
 func (s *Structure) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
+	yamlParser := config.NewYAML(s.configFileName, s.yamlOptions...)
 
 	yamlConfig, err := yamlParser.Parse()
 	if err != nil {
@@ -33,32 +43,47 @@ func (s *Structure) Build() error {
 
 	tg := generators.NewGenerator()
 
+	var errs []error
+
 	for i := range yamlConfig.Structure.Stacks {
 		conf := yamlConfig.Structure.Stacks[i]
 
 		data := Data{
-			StackName: conf.Name,
+			StackName:          conf.Name,
+			AWSProviderVersion: yamlConfig.GetAWSProviderConstraint(),
+			DefaultTags:        yamlConfig.DefaultTags(),
 		}
 
+		var stackErrs []error
+
 		for _, folder := range conf.Folders {
 			output := path.Join(s.output, conf.Name, folder.Name)
-			_ = os.MkdirAll(output, os.ModePerm)
+			if err := s.fs.MkdirAll(output); err != nil {
+				return fmt.Errorf("%w", err)
+			}
 
 			for _, file := range folder.Files {
 				outputFile := path.Join(output, file.Name)
 
-				generators.MustGenerateFile(tg, defaultTemplatesMap, file.Name, file.Tmpl, outputFile, data)
+				stackErrs = append(stackErrs,
+					generators.GenerateFile(tg, s.fs, defaultTemplatesMap, file.Name, file.Tmpl, outputFile, data))
 			}
 		}
 
 		for _, file := range conf.Files {
 			outputFile := path.Join(s.output, conf.Name, file.Name)
 
-			generators.MustGenerateFile(tg, defaultTemplatesMap, file.Name, file.Tmpl, outputFile, data)
+			stackErrs = append(stackErrs,
+				generators.GenerateFile(tg, s.fs, defaultTemplatesMap, file.Name, file.Tmpl, outputFile, data))
+		}
+
+		if err := errors.Join(stackErrs...); err != nil {
+			errs = append(errs, err)
+			continue
 		}
 
 		fmtcolor.White.Printf("Structure '%s' has been generated successfully\n", conf.Name)
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
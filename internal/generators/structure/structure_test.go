@@ -69,6 +69,39 @@ func TestStructure_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(teststackPath, "anyRootFile.txt"))
 			},
 		},
+		{
+			name: "required providers should be pinned to the aws provider version",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "structure.config.v5.yaml"),
+				output:         path.Join(testOutput, "v5"),
+			},
+			extraValidations: func(tb testing.TB, err error) {
+				if err != nil {
+					return
+				}
+
+				content, err := os.ReadFile(path.Join(testOutput, "v5", "teststack", "dev", "main.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(content), `version = "~> 5.0"`)
+			},
+		},
+		{
+			name: "default tags should be rendered in the provider",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "structure.config.defaulttags.yaml"),
+				output:         path.Join(testOutput, "defaulttags"),
+			},
+			extraValidations: func(tb testing.TB, err error) {
+				if err != nil {
+					return
+				}
+
+				content, err := os.ReadFile(path.Join(testOutput, "defaulttags", "teststack", "dev", "main.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(content), "default_tags {")
+				require.Contains(tb, string(content), `"team" = "payments"`)
+			},
+		},
 		{
 			name: "when yaml parser fails should return an error",
 			fields: fields{
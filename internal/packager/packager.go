@@ -0,0 +1,353 @@
+package packager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+)
+
+const (
+	// ArchAMD64 is the x86_64 architecture of the Lambda functions.
+	ArchAMD64 = "amd64"
+
+	// ArchARM64 is the arm64 architecture of the Lambda functions.
+	ArchARM64 = "arm64"
+)
+
+const (
+	goRuntimeArchitecture = ArchAMD64
+
+	filenameGoMod = "go.mod"
+
+	// The generated code only depends on aws-lambda-go, which is pinned so the build of the code outside a Go module
+	// stays reproducible.
+	goModVersion       = "1.21"
+	awsLambdaGoModule  = "github.com/aws/aws-lambda-go"
+	awsLambdaGoVersion = "v1.46.0"
+)
+
+// Architectures of the Go code by the architectures of the Lambda functions.
+var archByLambdaArchitecture = map[string]string{
+	config.LambdaArchitectureX86: ArchAMD64,
+	config.LambdaArchitectureARM: ArchARM64,
+}
+
+// ErrBuild represents a failure to compile the code of a Lambda.
+var ErrBuild = errors.New("build error")
+
+var execCommand = exec.Command
+
+// Artifact represents the zip file of a Lambda, referenced by the filename of its aws_lambda_function.
+type Artifact struct {
+	Name    string
+	Runtime string
+	Arch    string
+	CodeDir string
+	ZipFile string
+	Binary  string
+	Hash    string
+}
+
+type Packager struct {
+	configFileName string
+	output         string
+	stackName      string
+	yamlOptions    []config.YAMLOption
+	dryRun         bool
+}
+
+// Option is a functional option to configure the packager.
+type Option func(*Packager)
+
+// WithYAMLOptions sets the options used to load the configuration file.
+func WithYAMLOptions(opts ...config.YAMLOption) Option {
+	return func(p *Packager) {
+		p.yamlOptions = append(p.yamlOptions, opts...)
+	}
+}
+
+// WithDryRun lists the zips that would be built, without compiling the code or writing them.
+func WithDryRun() Option {
+	return func(p *Packager) {
+		p.dryRun = true
+	}
+}
+
+// NewPackager returns a packager of the Lambdas generated from the configuration file into the output folder. The
+// stack name is the folder of the Lambdas that are not part of an API Gateway.
+func NewPackager(configFileName, output, stackName string, opts ...Option) *Packager {
+	p := &Packager{configFileName: configFileName, output: output, stackName: stackName}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Build compiles the code of every Lambda that is not a module and zips it at the path its Terraform refers to. A
+// failure to package one of them does not stop the others. In dry-run mode, the artifacts are returned without a hash.
+func (p *Packager) Build() ([]Artifact, error) {
+	yamlConfig, err := config.NewYAML(p.configFileName, p.yamlOptions...).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	var (
+		artifacts []Artifact
+		errs      []error
+	)
+
+	for _, artifact := range p.artifacts(yamlConfig) {
+		if _, err := os.Stat(artifact.CodeDir); err != nil {
+			fmtcolor.Yellow.Printf("Lambda '%s' has not been generated at '%s' and has been skipped\n",
+				artifact.Name, artifact.CodeDir)
+
+			continue
+		}
+
+		if p.dryRun {
+			fmtcolor.White.Printf("Package '%s' would be built from '%s'\n", artifact.ZipFile, artifact.CodeDir)
+
+			artifacts = append(artifacts, artifact)
+
+			continue
+		}
+
+		if err := p.build(&artifact); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", artifact.Name, err))
+			continue
+		}
+
+		fmtcolor.White.Printf("Package '%s' has been built successfully: %s\n", artifact.ZipFile, artifact.Hash)
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, errors.Join(errs...)
+}
+
+// artifacts returns the Lambdas to package, following the paths of the lambda and apigateway generators. A relative
+// source is resolved from the stack folder.
+func (p *Packager) artifacts(yamlConfig *config.Config) []Artifact {
+	var artifacts []Artifact
+
+	stackOutput := filepath.Join(p.output, p.stackName)
+
+	for i := range yamlConfig.Lambdas {
+		lambdaConf := &yamlConfig.Lambdas[i]
+		if isModule(lambdaConf.Source) {
+			continue
+		}
+
+		goHandler := strcase.ToSnake(lambdaConf.Name)
+
+		artifacts = append(artifacts, Artifact{
+			Name:    lambdaConf.Name,
+			Runtime: lambdaConf.Runtime,
+			Arch:    lambdaArch(&lambdaConf.LambdaFunction),
+			CodeDir: filepath.Join(stackOutput, "lambda", lambdaConf.Name),
+			ZipFile: filepath.Join(resolveSource(lambdaConf.Source, stackOutput), goHandler+".zip"),
+			Binary:  binaryName(lambdaConf.Runtime, goHandler),
+		})
+	}
+
+	for i := range yamlConfig.APIGateways {
+		apiConf := &yamlConfig.APIGateways[i]
+		apiStackOutput := filepath.Join(p.output, apiConf.StackName)
+
+		for j := range apiConf.Lambdas {
+			lambdaConf := &apiConf.Lambdas[j]
+			if isModule(lambdaConf.Source) {
+				continue
+			}
+
+			goHandler := strcase.ToSnake(lambdaConf.Name) + "_lambda"
+
+			artifacts = append(artifacts, Artifact{
+				Name:    lambdaConf.Name,
+				Runtime: lambdaConf.Runtime,
+				Arch:    lambdaArch(&lambdaConf.LambdaFunction),
+				CodeDir: filepath.Join(apiStackOutput, "lambda", lambdaConf.Name),
+				ZipFile: filepath.Join(resolveSource(lambdaConf.Source, apiStackOutput), goHandler+".zip"),
+				Binary:  binaryName(lambdaConf.Runtime, goHandler),
+			})
+		}
+	}
+
+	return artifacts
+}
+
+func (p *Packager) build(artifact *Artifact) error {
+	if err := os.MkdirAll(filepath.Dir(artifact.ZipFile), os.ModePerm); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	buildDir, err := os.MkdirTemp("", "aws-terraform-generator-")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	var entries []zipEntry
+
+	if generators.RuntimeFamily(artifact.Runtime) == generators.RuntimeGo {
+		entries, err = p.compile(artifact, buildDir)
+	} else {
+		entries, err = sourceEntries(artifact.CodeDir, artifact.ZipFile)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	hash, err := writeZip(artifact.ZipFile, entries)
+	if err != nil {
+		return err
+	}
+
+	artifact.Hash = hash
+
+	return nil
+}
+
+// compile cross-compiles the Go code for Linux into the build folder, for the architecture the Terraform of the Lambda
+// deploys. The build is reproducible, so the hash only changes with the code. The generators do not write a go.mod, so
+// the code that does not belong to a Go module is copied into the build folder along with one.
+func (*Packager) compile(artifact *Artifact, buildDir string) ([]zipEntry, error) {
+	arch := artifact.Arch
+
+	// The go1.x runtime only runs on x86_64.
+	if !generators.IsCustomRuntime(artifact.Runtime) {
+		arch = goRuntimeArchitecture
+	}
+
+	binary := filepath.Join(buildDir, artifact.Binary)
+
+	args := []string{"build", "-trimpath", "-buildvcs=false", "-ldflags=-s -w -buildid=", "-tags=lambda.norpc"}
+	codeDir := artifact.CodeDir
+
+	inModule, err := inGoModule(codeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inModule {
+		codeDir = filepath.Join(buildDir, "src")
+
+		if err := copyCode(artifact, codeDir); err != nil {
+			return nil, err
+		}
+
+		args = append(args, "-mod=mod")
+	}
+
+	args = append(args, "-o", binary, ".")
+
+	cmd := execCommand("go", args...)
+	cmd.Dir = codeDir
+	cmd.Env = append(os.Environ(), "GOOS=linux", "GOARCH="+arch, "CGO_ENABLED=0")
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrBuild, err, strings.TrimSpace(string(output)))
+	}
+
+	return []zipEntry{{name: artifact.Binary, path: binary, mode: executableMode}}, nil
+}
+
+// inGoModule reports whether the folder, or one of its parents, has a go.mod.
+func inGoModule(dir string) (bool, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false, fmt.Errorf("%w", err)
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, filenameGoMod)); err == nil {
+			return true, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false, nil
+		}
+
+		dir = parent
+	}
+}
+
+// copyCode copies the code of the Lambda into the folder and writes its go.mod.
+func copyCode(artifact *Artifact, dir string) error {
+	entries, err := sourceEntries(artifact.CodeDir, artifact.ZipFile)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		data, err := os.ReadFile(entry.path)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		fileName := filepath.Join(dir, filepath.FromSlash(entry.name))
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		if err := os.WriteFile(fileName, data, fileMode); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo %s\n\nrequire %s %s\n",
+		artifact.Name, goModVersion, awsLambdaGoModule, awsLambdaGoVersion)
+
+	if err := os.WriteFile(filepath.Join(dir, filenameGoMod), []byte(goMod), fileMode); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// binaryName returns the name of the executable of a Go Lambda, which is its handler: bootstrap for the custom
+// runtimes, and the Go handler for the go1.x one.
+func binaryName(runtime, goHandler string) string {
+	if generators.IsCustomRuntime(runtime) {
+		return generators.HandlerCustomRuntime
+	}
+
+	return goHandler
+}
+
+// lambdaArch returns the architecture the Go code of the Lambda is compiled for, which is amd64 when the Lambda does
+// not set its architectures, like the x86_64 default of the aws_lambda_function.
+func lambdaArch(function *config.LambdaFunction) string {
+	if len(function.Architectures) == 0 {
+		return ArchAMD64
+	}
+
+	return archByLambdaArchitecture[function.Architectures[0]]
+}
+
+func isModule(source string) bool {
+	return strings.Contains(source, "git@")
+}
+
+func resolveSource(source, stackOutput string) string {
+	if filepath.IsAbs(source) {
+		return source
+	}
+
+	return filepath.Join(stackOutput, source)
+}
@@ -0,0 +1,125 @@
+package packager
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+)
+
+const testdataFolder = "testdata"
+
+func TestPackager_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		opts           []Option
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, []Artifact)
+		targetErr        error
+	}{
+		{
+			name: "happy path",
+			fields: fields{
+				configFileName: filepath.Join(testdataFolder, "package.config.yaml"),
+			},
+			extraValidations: func(tb testing.TB, output string, artifacts []Artifact) {
+				require.Len(tb, artifacts, 3)
+
+				require.Equal(tb, ArchARM64, artifacts[0].Arch)
+				require.Equal(tb, ArchAMD64, artifacts[2].Arch)
+
+				require.Equal(tb, filepath.Join(output, "teststack", "build", "order_processor.zip"), artifacts[0].ZipFile)
+				require.Equal(tb, []string{"bootstrap"}, zipNames(tb, artifacts[0].ZipFile))
+				require.NotEmpty(tb, artifacts[0].Hash)
+
+				require.Equal(tb, filepath.Join(output, "teststack", "build", "report_scheduler.zip"), artifacts[1].ZipFile)
+				require.Equal(tb, []string{"lambda_function.py", "requirements.txt"}, zipNames(tb, artifacts[1].ZipFile))
+
+				require.Equal(tb, filepath.Join(output, "apistack", "build", "orders_api_lambda.zip"), artifacts[2].ZipFile)
+				require.Equal(tb, []string{"orders_api_lambda"}, zipNames(tb, artifacts[2].ZipFile))
+
+				require.NoFileExists(tb, filepath.Join(output, "teststack", "build", "not_generated.zip"))
+				require.NoFileExists(tb, filepath.Join(output, "teststack", "build", "example_receiver.zip"))
+			},
+		},
+		{
+			name: "dry run should list the zips without writing them",
+			fields: fields{
+				configFileName: filepath.Join(testdataFolder, "package.config.yaml"),
+				opts:           []Option{WithDryRun()},
+			},
+			extraValidations: func(tb testing.TB, output string, artifacts []Artifact) {
+				require.Len(tb, artifacts, 3)
+
+				for _, artifact := range artifacts {
+					require.Empty(tb, artifact.Hash)
+					require.NoFileExists(tb, artifact.ZipFile)
+				}
+
+				require.NoDirExists(tb, filepath.Join(output, "teststack", "build"))
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			output := t.TempDir()
+
+			writeFile(t, filepath.Join(output, "teststack", "lambda", "orderProcessor", "main.go"),
+				"package main\n\nfunc main() {}\n")
+			writeFile(t, filepath.Join(output, "teststack", "lambda", "reportScheduler", "lambda_function.py"),
+				"def handler(event, context):\n    pass\n")
+			writeFile(t, filepath.Join(output, "teststack", "lambda", "reportScheduler", "requirements.txt"), "")
+			writeFile(t, filepath.Join(output, "apistack", "lambda", "ordersAPI", "main.go"),
+				"package main\n\nfunc main() {}\n")
+
+			artifacts, err := NewPackager(tc.fields.configFileName, output, "teststack", tc.fields.opts...).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, output, artifacts)
+			}
+		})
+	}
+}
+
+func writeFile(tb testing.TB, fileName, content string) {
+	tb.Helper()
+
+	require.NoError(tb, os.MkdirAll(filepath.Dir(fileName), os.ModePerm))
+	require.NoError(tb, os.WriteFile(fileName, []byte(content), 0o600))
+}
+
+func zipNames(tb testing.TB, zipFile string) []string {
+	tb.Helper()
+
+	r, err := zip.OpenReader(zipFile)
+	require.NoError(tb, err)
+
+	defer r.Close()
+
+	names := make([]string, 0, len(r.File))
+	for _, file := range r.File {
+		names = append(names, file.Name)
+	}
+
+	return names
+}
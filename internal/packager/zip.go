@@ -0,0 +1,117 @@
+package packager
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/filesystem"
+)
+
+const (
+	fileMode       fs.FileMode = 0o644
+	executableMode fs.FileMode = 0o755
+)
+
+// All entries share the same modification time, so the zip only changes with the content of the files.
+var zipModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// zipEntry represents a file added to the zip under the given name.
+type zipEntry struct {
+	name string
+	path string
+	mode fs.FileMode
+}
+
+// sourceEntries returns the files of the code folder, except the checksums of the generated files and the zip itself
+// when it is written inside the folder.
+func sourceEntries(codeDir, zipFile string) ([]zipEntry, error) {
+	absZipFile, err := filepath.Abs(zipFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	var entries []zipEntry
+
+	err = filepath.WalkDir(codeDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() || d.Name() == filesystem.ChecksumsFileName {
+			return err
+		}
+
+		if absPath, err := filepath.Abs(path); err == nil && absPath == absZipFile {
+			return nil
+		}
+
+		name, err := filepath.Rel(codeDir, path)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		entries = append(entries, zipEntry{name: filepath.ToSlash(name), path: path, mode: fileMode})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return entries, nil
+}
+
+// writeZip writes a deterministic zip of the entries, sorted by name and with fixed times and modes, and returns its
+// base64-encoded SHA256 hash, as computed by the filebase64sha256 Terraform function.
+func writeZip(zipFile string, entries []zipEntry) (string, error) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+
+	for _, entry := range entries {
+		if err := addZipEntry(w, entry); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	if err := os.WriteFile(zipFile, buf.Bytes(), fileMode); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+func addZipEntry(w *zip.Writer, entry zipEntry) error {
+	header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: zipModified}
+	header.SetMode(entry.mode)
+
+	writer, err := w.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	file, err := os.Open(entry.path)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(writer, file); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package packager
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteZip(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "code", "b.py"), "b")
+	writeFile(t, filepath.Join(dir, "code", "a", "a.py"), "a")
+
+	entries, err := sourceEntries(filepath.Join(dir, "code"), filepath.Join(dir, "code", "code.zip"))
+	require.NoError(t, err)
+
+	firstZip := filepath.Join(dir, "first.zip")
+
+	hash, err := writeZip(firstZip, entries)
+	require.NoError(t, err)
+
+	firstData, err := os.ReadFile(firstZip)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(firstData)
+	require.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), hash)
+
+	// The zip does not depend on the modification times of the files.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "code", "b.py"), later, later))
+
+	secondZip := filepath.Join(dir, "code", "code.zip")
+
+	secondHash, err := writeZip(secondZip, entries)
+	require.NoError(t, err)
+	require.Equal(t, hash, secondHash)
+	require.Equal(t, []string{"a/a.py", "b.py"}, zipNames(t, secondZip))
+}
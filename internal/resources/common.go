@@ -1,6 +1,10 @@
 package resources
 
-import "github.com/ettle/strcase"
+import (
+	"strings"
+
+	"github.com/ettle/strcase"
+)
 
 const (
 	EnvarSuffixDBHost           = "DB_HOST"
@@ -24,7 +28,43 @@ var (
 )
 
 var SuffixByResource = map[ResourceType]string{
-	KinesisType: "kinesis",
-	S3Type:      "bucket",
-	SQSType:     "sqs",
+	DatabaseType: "dynamodb",
+	DynamoDBType: "dynamodb",
+	KinesisType:  "kinesis",
+	S3Type:       "bucket",
+	SQSType:      "sqs",
+}
+
+// EnvarResourceType returns the type of the resource an environment variable of a Lambda refers to. The Terraform
+// resource its value refers to comes first. Failing that, the suffix of its name tells a database, a Google BigQuery
+// project or a restful API apart, and the name mentioning SQS/QUEUE, S3/BUCKET or KINESIS/STREAM tells the kind of
+// the queue, bucket or stream. It returns UnknownType for the other variables.
+func EnvarResourceType(key, value string) ResourceType {
+	switch {
+	case strings.HasPrefix(value, LabelAWSSQSQueue+"."):
+		return SQSType
+	case strings.HasPrefix(value, LabelAWSS3Bucket+"."):
+		return S3Type
+	case strings.HasPrefix(value, LabelAWSKinesisStream+"."):
+		return KinesisType
+	}
+
+	key = strings.ToUpper(key)
+
+	switch {
+	case strings.HasSuffix(key, EnvarSuffixDBHost):
+		return DatabaseType
+	case strings.HasSuffix(key, EnvarSuffixGoogleBQ):
+		return GoogleBQType
+	case strings.HasSuffix(key, EnvarSuffixRestfulAPI):
+		return RestfulAPIType
+	case strings.Contains(key, "SQS") || strings.Contains(key, "QUEUE"):
+		return SQSType
+	case strings.Contains(key, "S3") || strings.Contains(key, "BUCKET"):
+		return S3Type
+	case strings.Contains(key, "KINESIS") || strings.Contains(key, "STREAM"):
+		return KinesisType
+	default:
+		return UnknownType
+	}
 }
@@ -0,0 +1,36 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvarResourceType(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  ResourceType
+	}{
+		{name: "SQS queue reference", key: "ORDERS_URL", value: "aws_sqs_queue.orders_sqs.url", want: SQSType},
+		{name: "S3 bucket reference", key: "REPORTS", value: "aws_s3_bucket.reports_bucket.bucket", want: S3Type},
+		{name: "Kinesis stream reference", key: "EVENTS", value: "aws_kinesis_stream.events.name", want: KinesisType},
+		{name: "reference over name", key: "ORDERS_QUEUE_URL", value: "aws_kinesis_stream.orders.name", want: KinesisType},
+		{name: "database host", key: "ORDERS_DB_HOST", value: "var.orders_db_host", want: DatabaseType},
+		{name: "Google BigQuery project", key: "EVENTS_BQ_PROJECT_ID", value: "var.project", want: GoogleBQType},
+		{name: "restful API", key: "PAYMENTS_API_BASE_URL", value: "var.payments_url", want: RestfulAPIType},
+		{name: "queue name", key: "ORDERS_QUEUE_URL", value: "var.orders_queue_url", want: SQSType},
+		{name: "bucket name", key: "archive_bucket_name", value: "var.archive", want: S3Type},
+		{name: "stream name", key: "EVENTS_STREAM_NAME", value: "var.events", want: KinesisType},
+		{name: "other variable", key: "LOG_LEVEL", value: "info", want: UnknownType},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, EnvarResourceType(tc.key, tc.value))
+		})
+	}
+}
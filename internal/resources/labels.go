@@ -7,11 +7,14 @@ const (
 	LabelAWSAPIGatewayIntegration    = "aws_apigatewayv2_integration"
 	LabelAWSCloudwatchEventTarget    = "aws_cloudwatch_event_target"
 	LabelAWSCron                     = "aws_cloudwatch_event_rule"
+	LabelAWSDynamoDBTable            = "aws_dynamodb_table"
 	LabelAWSEndpoint                 = "aws_apigatewayv2_domain_name"
+	LabelAWSEventBus                 = "aws_cloudwatch_event_bus"
 	LabelAWSKinesisStream            = "aws_kinesis_stream"
 	LabelAWSLambdaFunction           = "aws_lambda_function"
 	LabelAWSLambdaEventSourceMapping = "aws_lambda_event_source_mapping"
 	LabelAWSS3Bucket                 = "aws_s3_bucket"
+	LabelAWSScheduler                = "aws_scheduler_schedule"
 	LabelAWSSQSQueue                 = "aws_sqs_queue"
 	LabelAWSSNSTopic                 = "aws_sns_topic"
 )
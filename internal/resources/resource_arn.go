@@ -22,15 +22,16 @@ var arnKeySuffix = map[string]string{
 }
 
 var labelByResourceType = map[ResourceType]string{
-	APIGatewayType: LabelAWSAPIGatewayRoute,
-	CronType:       LabelAWSCron,
-	EndpointType:   LabelAWSEndpoint,
-	KinesisType:    LabelAWSKinesisStream,
-	LambdaType:     LabelAWSLambdaFunction,
-	S3Type:         LabelAWSS3Bucket,
-	SQSType:        LabelAWSSQSQueue,
-	SNSType:        LabelAWSSNSTopic,
-	UnknownType:    "",
+	APIGatewayType:  LabelAWSAPIGatewayRoute,
+	CronType:        LabelAWSCron,
+	EndpointType:    LabelAWSEndpoint,
+	EventBridgeType: LabelAWSEventBus,
+	KinesisType:     LabelAWSKinesisStream,
+	LambdaType:      LabelAWSLambdaFunction,
+	S3Type:          LabelAWSS3Bucket,
+	SQSType:         LabelAWSSQSQueue,
+	SNSType:         LabelAWSSNSTopic,
+	UnknownType:     "",
 }
 
 type ResourceARN struct {
@@ -115,6 +116,8 @@ func parseARNTypeAndName(arn string) (arnType, name, label string) {
 
 func inferResourceType(arnType string) ResourceType {
 	switch arnType {
+	case LabelAWSEventBus:
+		return EventBridgeType
 	case LabelAWSKinesisStream:
 		return KinesisType
 	case LabelAWSLambdaFunction:
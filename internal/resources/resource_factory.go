@@ -12,8 +12,10 @@ type AWSResourceFactory struct{}
 // CreateResource creates a resource based on cell data.
 func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.Resource {
 	reAPIGateway := regexp.MustCompile("mxgraph.aws3.api_gateway|mxgraph.aws4.api_gateway")
-	reDatabase := regexp.MustCompile(`mxgraph.flowchart.database|mxgraph.aws3.dynamo_db|mxgraph.aws4.database|` +
+	reDatabase := regexp.MustCompile(`mxgraph.flowchart.database|mxgraph.aws4.database|` +
 		`mxgraph.aws4.documentdb_with_mongodb_compatibility`)
+	reDynamoDB := regexp.MustCompile(`mxgraph.aws3.dynamo_db|mxgraph.aws4.dynamodb`)
+	reEventBridge := regexp.MustCompile(`mxgraph.aws4.eventbridge|mxgraph.aws4.event_event_based`)
 	reGoogleBQ := regexp.MustCompile("mxgraph.gcp2.big_query|google_bigquery")
 	reKinesis := regexp.MustCompile(`mxgraph.aws3.kinesis|mxgraph.aws4.kinesis`)
 	resLambda := regexp.MustCompile(`mxgraph.aws3.lambda|mxgraph.aws4.lambda`)
@@ -23,14 +25,20 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 	reSNS := regexp.MustCompile(`mxgraph.aws3.sns|mxgraph.aws4.sns`)
 
 	switch {
+	case reAPIGateway.MatchString(style) && isWebSocketAPI(value, style):
+		return resources.NewGenericResource(id, value, WebSocketAPIType.String())
 	case reAPIGateway.MatchString(style):
 		return resources.NewGenericResource(id, value, APIGatewayType.String())
 	case strings.Contains(style, "mxgraph.aws4.event_time_based"):
 		return resources.NewGenericResource(id, value, CronType.String())
+	case reDynamoDB.MatchString(style):
+		return resources.NewGenericResource(id, value, DynamoDBType.String())
 	case reDatabase.MatchString(style):
 		return resources.NewGenericResource(id, value, DatabaseType.String())
 	case strings.Contains(style, "mxgraph.aws4.endpoint"):
 		return resources.NewGenericResource(id, value, EndpointType.String())
+	case reEventBridge.MatchString(style):
+		return resources.NewGenericResource(id, value, EventBridgeType.String())
 	case reGoogleBQ.MatchString(style):
 		return resources.NewGenericResource(id, value, GoogleBQType.String())
 	case reKinesis.MatchString(style):
@@ -49,3 +57,9 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 		return nil
 	}
 }
+
+// isWebSocketAPI returns true when the API Gateway shape is marked as a WebSocket API in its style, or when its value
+// is a route key that only WebSocket APIs have.
+func isWebSocketAPI(value, style string) bool {
+	return strings.Contains(strings.ToLower(style), "websocket") || value == "$connect" || value == "$disconnect"
+}
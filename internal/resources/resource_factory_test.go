@@ -28,6 +28,24 @@ func TestAWSResourceFactory_CreateResource(t *testing.T) {
 			},
 			want: resources.NewGenericResource("APIG_ID", "myAPI", APIGatewayType.String()),
 		},
+		{
+			name: "WebSocket API Resource",
+			args: args{
+				id:    "WS_ID",
+				value: "sendMessage",
+				style: "mxgraph.aws4.api_gateway;websocket=1",
+			},
+			want: resources.NewGenericResource("WS_ID", "sendMessage", WebSocketAPIType.String()),
+		},
+		{
+			name: "WebSocket API Resource by its route key",
+			args: args{
+				id:    "WS_ID",
+				value: "$connect",
+				style: "mxgraph.aws4.api_gateway",
+			},
+			want: resources.NewGenericResource("WS_ID", "$connect", WebSocketAPIType.String()),
+		},
 		{
 			name: "Cron Resource",
 			args: args{
@@ -46,6 +64,24 @@ func TestAWSResourceFactory_CreateResource(t *testing.T) {
 			},
 			want: resources.NewGenericResource("DB_ID", "myDB", DatabaseType.String()),
 		},
+		{
+			name: "DocumentDB Resource",
+			args: args{
+				id:    "DOCDB_ID",
+				value: "myDocDB",
+				style: "mxgraph.aws4.documentdb_with_mongodb_compatibility",
+			},
+			want: resources.NewGenericResource("DOCDB_ID", "myDocDB", DatabaseType.String()),
+		},
+		{
+			name: "DynamoDB Resource",
+			args: args{
+				id:    "DYNAMODB_ID",
+				value: "myTable",
+				style: "mxgraph.aws4.dynamodb",
+			},
+			want: resources.NewGenericResource("DYNAMODB_ID", "myTable", DynamoDBType.String()),
+		},
 		{
 			name: "Endpoint Resource",
 			args: args{
@@ -55,6 +91,15 @@ func TestAWSResourceFactory_CreateResource(t *testing.T) {
 			},
 			want: resources.NewGenericResource("ENDPOINT_ID", "myEndpoint", EndpointType.String()),
 		},
+		{
+			name: "EventBridge Resource",
+			args: args{
+				id:    "EVENTBRIDGE_ID",
+				value: "orders",
+				style: "mxgraph.aws4.eventbridge_custom_event_bus_resource",
+			},
+			want: resources.NewGenericResource("EVENTBRIDGE_ID", "orders", EventBridgeType.String()),
+		},
 		{
 			name: "GoogleBQ Resource",
 			args: args{
@@ -16,9 +16,15 @@ const (
 	// DatabaseType represents the Database resource type.
 	DatabaseType ResourceType = "database"
 
+	// DynamoDBType represents the DynamoDB resource type.
+	DynamoDBType ResourceType = "dynamodb"
+
 	// EndpointType represents the Endpoint resource type.
 	EndpointType ResourceType = "endpoint"
 
+	// EventBridgeType represents the EventBridge resource type.
+	EventBridgeType ResourceType = "eventbridge"
+
 	// GoogleBQType represents the Google BigQuery resource type.
 	GoogleBQType ResourceType = "googlebq"
 
@@ -40,6 +46,9 @@ const (
 	// SQSType represents the SQS resource type.
 	SQSType ResourceType = "sqs"
 
+	// WebSocketAPIType represents the WebSocket API resource type.
+	WebSocketAPIType ResourceType = "websocketapi"
+
 	// UnknownType represents an unknown resource type.
 	UnknownType ResourceType = "unknown"
 )
@@ -48,7 +57,9 @@ var AvailableTypes = []string{
 	APIGatewayType.String(),
 	CronType.String(),
 	DatabaseType.String(),
+	DynamoDBType.String(),
 	EndpointType.String(),
+	EventBridgeType.String(),
 	GoogleBQType.String(),
 	KinesisType.String(),
 	LambdaType.String(),
@@ -56,6 +67,7 @@ var AvailableTypes = []string{
 	S3Type.String(),
 	SQSType.String(),
 	SNSType.String(),
+	WebSocketAPIType.String(),
 }
 
 // String returns the string representation of a ResourceType.
@@ -67,8 +79,12 @@ func (rt ResourceType) String() string {
 		return "Cron"
 	case DatabaseType:
 		return "Database"
+	case DynamoDBType:
+		return "DynamoDB"
 	case EndpointType:
 		return "Endpoint"
+	case EventBridgeType:
+		return "EventBridge"
 	case GoogleBQType:
 		return "GoogleBQ"
 	case KinesisType:
@@ -83,6 +99,8 @@ func (rt ResourceType) String() string {
 		return "SNS"
 	case SQSType:
 		return "SQS"
+	case WebSocketAPIType:
+		return "WebSocketAPI"
 	default:
 		return "Unknown"
 	}
@@ -97,8 +115,12 @@ func ParseResourceType(s string) ResourceType {
 		return CronType
 	case "database":
 		return DatabaseType
+	case "dynamodb":
+		return DynamoDBType
 	case "endpoint":
 		return EndpointType
+	case "eventbridge":
+		return EventBridgeType
 	case "googlebq":
 		return GoogleBQType
 	case "kinesis":
@@ -113,6 +135,8 @@ func ParseResourceType(s string) ResourceType {
 		return SNSType
 	case "sqs":
 		return SQSType
+	case "websocketapi":
+		return WebSocketAPIType
 	default:
 		return UnknownType
 	}
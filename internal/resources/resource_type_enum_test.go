@@ -15,7 +15,9 @@ func TestResourceType_String(t *testing.T) {
 		{name: "APIGateway", rt: APIGatewayType, want: "APIGateway"},
 		{name: "Cron", rt: CronType, want: "Cron"},
 		{name: "Database", rt: DatabaseType, want: "Database"},
+		{name: "DynamoDB", rt: DynamoDBType, want: "DynamoDB"},
 		{name: "Endpoint", rt: EndpointType, want: "Endpoint"},
+		{name: "EventBridge", rt: EventBridgeType, want: "EventBridge"},
 		{name: "GoogleBQ", rt: GoogleBQType, want: "GoogleBQ"},
 		{name: "Kinesis", rt: KinesisType, want: "Kinesis"},
 		{name: "Lambda", rt: LambdaType, want: "Lambda"},
@@ -23,6 +25,7 @@ func TestResourceType_String(t *testing.T) {
 		{name: "S3", rt: S3Type, want: "S3"},
 		{name: "SQS", rt: SQSType, want: "SQS"},
 		{name: "SNS", rt: SNSType, want: "SNS"},
+		{name: "WebSocketAPI", rt: WebSocketAPIType, want: "WebSocketAPI"},
 		{name: "Unknown", rt: "", want: "Unknown"},
 	}
 
@@ -46,7 +49,9 @@ func TestParseResourceType(t *testing.T) {
 		{name: "Parse APIGateway", input: "APIGateway", output: APIGatewayType},
 		{name: "Parse Cron", input: "Cron", output: CronType},
 		{name: "Parse Database", input: "Database", output: DatabaseType},
+		{name: "Parse DynamoDB", input: "DynamoDB", output: DynamoDBType},
 		{name: "Parse Endpoint", input: "Endpoint", output: EndpointType},
+		{name: "Parse EventBridge", input: "EventBridge", output: EventBridgeType},
 		{name: "Parse GoogleBQ", input: "GoogleBQ", output: GoogleBQType},
 		{name: "Parse Kinesis", input: "Kinesis", output: KinesisType},
 		{name: "Parse Lambda", input: "Lambda", output: LambdaType},
@@ -54,6 +59,7 @@ func TestParseResourceType(t *testing.T) {
 		{name: "Parse S3", input: "S3", output: S3Type},
 		{name: "Parse SQS", input: "SQS", output: SQSType},
 		{name: "Parse SNS", input: "SNS", output: SNSType},
+		{name: "Parse WebSocketAPI", input: "WebSocketAPI", output: WebSocketAPIType},
 		{name: "Parse Unknown", input: "Unknown", output: UnknownType},
 		{name: "Parse lowercase", input: "sqs", output: SQSType},
 		{name: "Parse uppercase", input: "SNS", output: SNSType},
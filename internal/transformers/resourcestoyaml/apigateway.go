@@ -1,12 +1,25 @@
 package resourcestoyaml
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/ettle/strcase"
+
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
+// apiGatewayIntegration represents an integration of the API Gateway of the diagram with the queue, stream or restful
+// API of the target.
+type apiGatewayIntegration struct {
+	apiGatewayID string
+	targetID     string
+	conf         config.APIGatewayIntegration
+}
+
 func (t *Transformer) buildAPIGatewayRelationship(source, target resources.Resource) {
 	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.EndpointType {
 		t.buildEndpointToAPIGateway(source, target)
@@ -25,12 +38,73 @@ func (t *Transformer) buildAPIGateways(
 		}
 
 		apiGateways = append(apiGateways, config.APIGateway{
-			StackName: t.yamlConfig.Diagram.StackName,
-			APIG:      true,
-			APIDomain: apiDomainValue,
-			Lambdas:   apiGatewayLambdasByAPIGatewayID[apigID],
+			StackName:   t.yamlConfig.Diagram.StackName,
+			APIG:        true,
+			APIDomain:   apiDomainValue,
+			Authorizers: t.yamlConfig.Diagram.APIGateway.Authorizers,
+			CORS:        t.yamlConfig.Diagram.APIGateway.CORS,
+			Throttling:  t.yamlConfig.Diagram.APIGateway.Throttling,
+			Stages:      t.yamlConfig.Diagram.APIGateway.Stages,
+			Lambdas:     apiGatewayLambdasByAPIGatewayID[apigID],
+		})
+
+		for i := range t.apiGatewayIntegrations {
+			if t.apiGatewayIntegrations[i].apiGatewayID == apigID {
+				apiGateways[len(apiGateways)-1].Integrations = append(apiGateways[len(apiGateways)-1].Integrations,
+					t.apiGatewayIntegrations[i].conf)
+			}
+		}
+	}
+
+	for _, webSocketAPI := range t.resourcesByTypeMap[awsresources.WebSocketAPIType] {
+		apiGateways = append(apiGateways, config.APIGateway{
+			StackName:    t.yamlConfig.Diagram.StackName,
+			APIG:         true,
+			ProtocolType: config.APIGatewayProtocolWebSocket,
+			Throttling:   t.yamlConfig.Diagram.APIGateway.Throttling,
+			Stages:       t.yamlConfig.Diagram.APIGateway.Stages,
+			Lambdas:      apiGatewayLambdasByAPIGatewayID[webSocketAPI.ID()],
 		})
 	}
 
 	return apiGateways
 }
+
+// buildAPIGatewayIntegration adds the route of the API Gateway to the integration with the target, which is created
+// by the first API Gateway routing to the target.
+func (t *Transformer) buildAPIGatewayIntegration(
+	apiGateway, target resources.Resource, integrationType, integrationTarget string,
+) {
+	route := apiGatewayRoute(apiGateway)
+
+	for i := range t.apiGatewayIntegrations {
+		if t.apiGatewayIntegrations[i].targetID == target.ID() {
+			t.apiGatewayIntegrations[i].conf.Routes = append(t.apiGatewayIntegrations[i].conf.Routes, route)
+			return
+		}
+	}
+
+	t.apiGatewayIntegrations = append(t.apiGatewayIntegrations, apiGatewayIntegration{
+		apiGatewayID: apiGateway.ID(),
+		targetID:     target.ID(),
+		conf: config.APIGatewayIntegration{
+			APIGatewayRouteSettings: config.APIGatewayRouteSettings{
+				Authorizer: t.yamlConfig.Diagram.APIGateway.Authorizer,
+			},
+			Name:   strcase.ToCamel(fmt.Sprintf("%s_integration", target.Value())),
+			Type:   integrationType,
+			Target: integrationTarget,
+			Routes: []config.APIGatewayRoute{route},
+		},
+	})
+}
+
+// apiGatewayRoute returns the route of the API Gateway, whose value is the verb and the path, or $default.
+func apiGatewayRoute(apiGateway resources.Resource) config.APIGatewayRoute {
+	verb, path, found := strings.Cut(apiGateway.Value(), " ")
+	if !found {
+		return config.APIGatewayRoute{Path: verb}
+	}
+
+	return config.APIGatewayRoute{Verb: verb, Path: path}
+}
@@ -3,6 +3,7 @@ package resourcestoyaml
 import (
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
@@ -11,3 +12,17 @@ func (t *Transformer) buildDatabaseRelationship(source, target resources.Resourc
 		t.buildLambdaToDatabase(source, target)
 	}
 }
+
+func (t *Transformer) buildDynamoDBs() []config.DynamoDB {
+	var dynamoDBs []config.DynamoDB
+
+	for _, database := range t.resourcesByTypeMap[awsresources.DynamoDBType] {
+		dynamoDBs = append(dynamoDBs, config.DynamoDB{
+			Name:        database.Value(),
+			BillingMode: "PAY_PER_REQUEST",
+			HashKey:     "id",
+		})
+	}
+
+	return dynamoDBs
+}
@@ -0,0 +1,39 @@
+package resourcestoyaml
+
+import (
+	"fmt"
+
+	"github.com/diagram-code-generator/resources/pkg/resources"
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+func (t *Transformer) buildEventBridgeToTarget(eventBridge, target resources.Resource, targetType string) {
+	eventBridgeID := eventBridge.ID()
+	t.targetsByEventBridgeID[eventBridgeID] = append(t.targetsByEventBridgeID[eventBridgeID],
+		config.EventBridgeTarget{Type: targetType, Target: target.Value()})
+}
+
+// buildEventBridges creates a bus per EventBridge of the diagram, with a rule sending the events of the bus to the
+// resources it is connected to.
+func (t *Transformer) buildEventBridges() []config.EventBridge {
+	var eventBridges []config.EventBridge
+
+	for _, eb := range t.resourcesByTypeMap[awsresources.EventBridgeType] {
+		var rules []config.EventBridgeRule
+
+		if targets := t.targetsByEventBridgeID[eb.ID()]; len(targets) > 0 {
+			rules = append(rules, config.EventBridgeRule{
+				Name:         strcase.ToCamel(eb.Value() + " rule"),
+				EventPattern: fmt.Sprintf(`{"source":[%q]}`, eb.Value()),
+				Targets:      targets,
+			})
+		}
+
+		eventBridges = append(eventBridges, config.EventBridge{Name: eb.Value(), Rules: rules})
+	}
+
+	return eventBridges
+}
@@ -8,7 +8,12 @@ import (
 )
 
 func (t *Transformer) buildKinesisRelationship(source, target resources.Resource) {
-	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.LambdaType {
+	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.APIGatewayType:
+		t.buildAPIGatewayToKinesis(source, target)
+	case awsresources.EventBridgeType:
+		t.buildEventBridgeToTarget(source, target, config.EventBridgeTargetKinesis)
+	case awsresources.LambdaType:
 		t.buildLambdaToKinesis(source, target)
 	}
 }
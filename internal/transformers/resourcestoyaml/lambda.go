@@ -2,7 +2,6 @@ package resourcestoyaml
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ettle/strcase"
 
@@ -16,6 +15,8 @@ func (t *Transformer) buildLambdaRelationships(source, target resources.Resource
 	switch awsresources.ParseResourceType(source.ResourceType()) {
 	case awsresources.CronType:
 		t.buildCronToLambda(source, target)
+	case awsresources.EventBridgeType:
+		t.buildEventBridgeToTarget(source, target, config.EventBridgeTargetLambda)
 	case awsresources.KinesisType:
 		t.buildKinesisToLambda(source, target)
 	case awsresources.SQSType:
@@ -29,34 +30,60 @@ func (t *Transformer) buildLambdas() (
 	lambdas []config.Lambda, apiGatewayLambdasByAPIGatewayID map[string][]config.APIGatewayLambda,
 ) {
 	apiGatewayLambdasByAPIGatewayID = map[string][]config.APIGatewayLambda{}
-	apiGatewayLambdaIDs := map[string]struct{}{}
+	apiGatewayIDByLambdaID := map[string]string{}
 
 	for _, rel := range t.resc.Relationships {
+		sourceType := awsresources.ParseResourceType(rel.Source.ResourceType())
+
 		isAPIGatewayLambda := awsresources.ParseResourceType(rel.Target.ResourceType()) == awsresources.LambdaType &&
-			awsresources.ParseResourceType(rel.Source.ResourceType()) == awsresources.APIGatewayType
-
-		if isAPIGatewayLambda {
-			lambda := rel.Target
-			apiGatewayID := rel.Source.ID()
-
-			apiGatewayLambdasByAPIGatewayID[apiGatewayID] = append(
-				apiGatewayLambdasByAPIGatewayID[apiGatewayID], config.APIGatewayLambda{
-					Name:        lambda.Value(),
-					Source:      t.yamlConfig.Diagram.Lambda.Source,
-					RoleName:    t.yamlConfig.Diagram.Lambda.RoleName,
-					Runtime:     t.yamlConfig.Diagram.Lambda.Runtime,
-					Description: fmt.Sprintf("%s lambda", lambda.Value()),
-					Envars:      t.envars[lambda.ID()],
-					Verb:        strings.Split(rel.Source.Value(), " ")[0],
-					Path:        strings.Split(rel.Source.Value(), " ")[1],
-				})
-
-			apiGatewayLambdaIDs[lambda.ID()] = struct{}{}
+			(sourceType == awsresources.APIGatewayType || sourceType == awsresources.WebSocketAPIType)
+
+		if !isAPIGatewayLambda {
+			continue
+		}
+
+		lambda := rel.Target
+		route := apiGatewayRoute(rel.Source)
+
+		// A Lambda served by several API Gateways is added to the first one, with a route for each of them.
+		if apiGatewayID, ok := apiGatewayIDByLambdaID[lambda.ID()]; ok {
+			apiGatewayLambdas := apiGatewayLambdasByAPIGatewayID[apiGatewayID]
+			for i := range apiGatewayLambdas {
+				if apiGatewayLambdas[i].Name == lambda.Value() {
+					apiGatewayLambdas[i].Routes = append(apiGatewayLambdas[i].Routes, route)
+				}
+			}
+
+			continue
+		}
+
+		apiGatewayID := rel.Source.ID()
+
+		// WebSocket APIs do not support the authorizers.
+		var routeSettings config.APIGatewayRouteSettings
+		if sourceType == awsresources.APIGatewayType {
+			routeSettings.Authorizer = t.yamlConfig.Diagram.APIGateway.Authorizer
 		}
+
+		apiGatewayLambdasByAPIGatewayID[apiGatewayID] = append(
+			apiGatewayLambdasByAPIGatewayID[apiGatewayID], config.APIGatewayLambda{
+				Name:        lambda.Value(),
+				Source:      t.yamlConfig.Diagram.Lambda.Source,
+				RoleName:    t.yamlConfig.Diagram.Lambda.RoleName,
+				Runtime:     t.yamlConfig.Diagram.Lambda.Runtime,
+				Description: fmt.Sprintf("%s lambda", lambda.Value()),
+				Envars:      t.envars[lambda.ID()],
+				Verb:        route.Verb,
+				Path:        route.Path,
+
+				APIGatewayRouteSettings: routeSettings,
+			})
+
+		apiGatewayIDByLambdaID[lambda.ID()] = apiGatewayID
 	}
 
 	for _, lambda := range t.resourcesByTypeMap[awsresources.LambdaType] {
-		if _, ok := apiGatewayLambdaIDs[lambda.ID()]; ok {
+		if _, ok := apiGatewayIDByLambdaID[lambda.ID()]; ok {
 			continue
 		}
 
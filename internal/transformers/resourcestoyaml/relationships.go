@@ -7,8 +7,23 @@ import (
 	"github.com/ettle/strcase"
 
 	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 )
 
+func (t *Transformer) buildAPIGatewayToKinesis(apiGateway, kinesis resources.Resource) {
+	t.buildAPIGatewayIntegration(apiGateway, kinesis, config.APIGatewayIntegrationKinesis, kinesis.Value())
+}
+
+func (t *Transformer) buildAPIGatewayToRestfulAPI(apiGateway, restfulAPI resources.Resource) {
+	t.buildAPIGatewayIntegration(apiGateway, restfulAPI, config.APIGatewayIntegrationHTTP,
+		"var."+strcase.ToSnake(fmt.Sprintf("%s%s", restfulAPI.Value(), "API_BASE_URL")))
+}
+
+func (t *Transformer) buildAPIGatewayToSQS(apiGateway, sqs resources.Resource) {
+	t.buildAPIGatewayIntegration(apiGateway, sqs, config.APIGatewayIntegrationSQS, sqs.Value())
+}
+
 func (t *Transformer) buildCronToLambda(cron, lambda resources.Resource) {
 	t.cronsByLambdaID[lambda.ID()] = cron
 }
@@ -8,7 +8,10 @@ import (
 )
 
 func (t *Transformer) buildRestfulAPIRelationship(source, target resources.Resource) {
-	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.LambdaType {
+	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.APIGatewayType:
+		t.buildAPIGatewayToRestfulAPI(source, target)
+	case awsresources.LambdaType:
 		t.buildLambdaToRestfulAPI(source, target)
 	}
 }
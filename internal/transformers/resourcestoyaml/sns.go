@@ -8,7 +8,10 @@ import (
 )
 
 func (t *Transformer) buildSNSRelationship(source, target resources.Resource) {
-	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.S3Type {
+	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.EventBridgeType:
+		t.buildEventBridgeToTarget(source, target, config.EventBridgeTargetSNS)
+	case awsresources.S3Type:
 		t.buildS3ToSNS(source, target)
 	}
 }
@@ -16,35 +19,26 @@ func (t *Transformer) buildSNSRelationship(source, target resources.Resource) {
 func (t *Transformer) buildSNSs() []config.SNS {
 	var snss []config.SNS
 
-	snsResourceEvents := []string{"s3:ObjectCreated:*"}
-
 	for _, s := range t.resourcesByTypeMap[awsresources.SNSType] {
 		var bucketName string
 		if s3Bucket, ok := t.s3BucketsBySNSID[s.ID()]; ok {
 			bucketName = s3Bucket.Value()
 		}
 
-		var lambdas []config.SNSResource
-		for _, l := range t.lambdasBySNSID[s.ID()] {
-			lambdas = append(lambdas, config.SNSResource{
-				Name:   l.Value(),
-				Events: snsResourceEvents,
-			})
-		}
+		var subscriptions []config.SNSSubscription
 
-		var sqss []config.SNSResource
 		for _, sqs := range t.sqssBySNSID[s.ID()] {
-			sqss = append(sqss, config.SNSResource{
-				Name:   sqs.Value(),
-				Events: snsResourceEvents,
-			})
+			subscriptions = append(subscriptions, config.SNSSubscription{Protocol: "sqs", Endpoint: sqs.Value()})
+		}
+
+		for _, l := range t.lambdasBySNSID[s.ID()] {
+			subscriptions = append(subscriptions, config.SNSSubscription{Protocol: "lambda", Endpoint: l.Value()})
 		}
 
 		snss = append(snss, config.SNS{
-			Name:       s.Value(),
-			BucketName: bucketName,
-			Lambdas:    lambdas,
-			SQSs:       sqss,
+			Name:          s.Value(),
+			BucketName:    bucketName,
+			Subscriptions: subscriptions,
 		})
 	}
 
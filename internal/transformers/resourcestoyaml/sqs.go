@@ -7,8 +7,19 @@ import (
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
+// Defaults of the queues drawn in the diagram: the DLQ keeps the failed messages for the maximum of 14 days.
+const (
+	defaultSQSMaxReceiveCount          = 10
+	defaultSQSVisibilityTimeoutSeconds = 720
+	defaultSQSDLQRetentionSeconds      = 1209600
+)
+
 func (t *Transformer) buildSQSRelationships(source, target resources.Resource) {
 	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.APIGatewayType:
+		t.buildAPIGatewayToSQS(source, target)
+	case awsresources.EventBridgeType:
+		t.buildEventBridgeToTarget(source, target, config.EventBridgeTargetSQS)
 	case awsresources.LambdaType:
 		t.buildLambdaToSQS(source, target)
 	case awsresources.SNSType:
@@ -20,8 +31,18 @@ func (t *Transformer) buildSQSs() []config.SQS {
 	var sqss []config.SQS
 
 	for _, sqs := range t.resourcesByTypeMap[awsresources.SQSType] {
-		sqss = append(sqss, config.SQS{Name: sqs.Value(), MaxReceiveCount: 10})
+		sqss = append(sqss, newSQS(sqs.Value()))
 	}
 
 	return sqss
 }
+
+func newSQS(name string) config.SQS {
+	return config.SQS{
+		Name:                     name,
+		MaxReceiveCount:          defaultSQSMaxReceiveCount,
+		VisibilityTimeoutSeconds: defaultSQSVisibilityTimeoutSeconds,
+		SQSManagedSSE:            true,
+		DLQ:                      config.SQSDLQ{MessageRetentionSeconds: defaultSQSDLQRetentionSeconds},
+	}
+}
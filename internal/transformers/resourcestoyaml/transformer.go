@@ -18,9 +18,12 @@ type Transformer struct {
 	s3BucketsBySNSID          map[string]resources.Resource
 	sqssBySNSID               map[string][]resources.Resource
 	sqsTriggersByLambdaID     map[string][]resources.Resource
+	targetsByEventBridgeID    map[string][]config.EventBridgeTarget
 
 	envars map[string]map[string]string
 
+	apiGatewayIntegrations []apiGatewayIntegration
+
 	resourcesByTypeMap map[awsresources.ResourceType][]resources.Resource
 }
 
@@ -36,6 +39,7 @@ func NewTransformer(yamlConfig *config.Config, resc *resources.ResourceCollectio
 		s3BucketsBySNSID:          map[string]resources.Resource{},
 		sqsTriggersByLambdaID:     map[string][]resources.Resource{},
 		sqssBySNSID:               map[string][]resources.Resource{},
+		targetsByEventBridgeID:    map[string][]config.EventBridgeTarget{},
 
 		envars: map[string]map[string]string{},
 
@@ -50,6 +54,8 @@ func (t *Transformer) Transform() (*config.Config, error) {
 
 	lambdas, apiGatewayLambdasByAPIGatewayID := t.buildLambdas()
 	apiGateways := t.buildAPIGateways(apiGatewayLambdasByAPIGatewayID)
+	dynamoDBs := t.buildDynamoDBs()
+	eventBridges := t.buildEventBridges()
 	kinesis := t.buildKinesis()
 	snss := t.buildSNSs()
 	sqss := t.buildSQSs()
@@ -57,13 +63,15 @@ func (t *Transformer) Transform() (*config.Config, error) {
 	restfulAPIs := t.buildRestfulAPIs()
 
 	return &config.Config{
-		Lambdas:     lambdas,
-		APIGateways: apiGateways,
-		Kinesis:     kinesis,
-		SNSs:        snss,
-		SQSs:        sqss,
-		Buckets:     buckets,
-		RestfulAPIs: restfulAPIs,
+		Lambdas:      lambdas,
+		APIGateways:  apiGateways,
+		DynamoDBs:    dynamoDBs,
+		EventBridges: eventBridges,
+		Kinesis:      kinesis,
+		SNSs:         snss,
+		SQSs:         sqss,
+		Buckets:      buckets,
+		RestfulAPIs:  restfulAPIs,
 	}, nil
 }
 
@@ -84,7 +92,7 @@ func (t *Transformer) buildResourceRelationships() {
 			t.buildAPIGatewayRelationship(source, target)
 		case awsresources.GoogleBQType:
 			t.buildGoogleBQRelationship(source, target)
-		case awsresources.DatabaseType:
+		case awsresources.DatabaseType, awsresources.DynamoDBType:
 			t.buildDatabaseRelationship(source, target)
 		case awsresources.KinesisType:
 			t.buildKinesisRelationship(source, target)
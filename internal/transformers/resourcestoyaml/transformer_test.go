@@ -21,6 +21,14 @@ var diagramConfig = &config.Config{
 	},
 }
 
+var myQueueSQS = config.SQS{
+	Name:                     "my-queue",
+	MaxReceiveCount:          10,
+	VisibilityTimeoutSeconds: 720,
+	SQSManagedSSE:            true,
+	DLQ:                      config.SQSDLQ{MessageRetentionSeconds: 1209600},
+}
+
 func TestTransformDrawIOToYAML_APIGateway(t *testing.T) {
 	type args struct {
 		yamlConfig *config.Config
@@ -30,6 +38,12 @@ func TestTransformDrawIOToYAML_APIGateway(t *testing.T) {
 	endpointResource := resources.NewGenericResource("id1", "https://my-domain.com", awsresources.EndpointType.String())
 	apiGatewayResource := resources.NewGenericResource("id2", "POST /examples", awsresources.APIGatewayType.String())
 	lambdaResource := resources.NewGenericResource("id3", "my-lambda", awsresources.LambdaType.String())
+	getAPIGatewayResource := resources.NewGenericResource("id4", "GET /examples", awsresources.APIGatewayType.String())
+	sqsResource := resources.NewGenericResource("id5", "my-queue", awsresources.SQSType.String())
+	kinesisResource := resources.NewGenericResource("id6", "my-stream", awsresources.KinesisType.String())
+	restfulAPIResource := resources.NewGenericResource("id7", "my-api", awsresources.RestfulAPIType.String())
+	connectResource := resources.NewGenericResource("id8", "$connect", awsresources.WebSocketAPIType.String())
+	sendMessageResource := resources.NewGenericResource("id9", "sendMessage", awsresources.WebSocketAPIType.String())
 
 	tests := []struct {
 		name      string
@@ -90,6 +104,180 @@ func TestTransformDrawIOToYAML_APIGateway(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "API Gateway with the authorizers, CORS, throttling and stages of the diagram",
+			args: args{
+				yamlConfig: &config.Config{
+					Diagram: config.Diagram{
+						StackName: "my-stack",
+						Lambda:    config.DriagramLambda{Source: "git@", RoleName: "execute_lambda"},
+						APIGateway: config.DiagramAPIGateway{
+							Authorizers: []config.APIGatewayAuthorizer{
+								{Name: "cognito", Type: "JWT", Issuer: "https://issuer.com", Audience: []string{"app"}},
+							},
+							Authorizer: "cognito",
+							CORS:       config.APIGatewayCORS{AllowOrigins: []string{"*"}},
+							Throttling: config.APIGatewayThrottling{BurstLimit: 100, RateLimit: 50},
+							Stages:     []config.APIGatewayStage{{Name: "v1"}},
+						},
+					},
+				},
+				resources: &resources.ResourceCollection{
+					Resources:     []resources.Resource{apiGatewayResource, lambdaResource},
+					Relationships: []resources.Relationship{{Source: apiGatewayResource, Target: lambdaResource}},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Authorizers: []config.APIGatewayAuthorizer{
+							{Name: "cognito", Type: "JWT", Issuer: "https://issuer.com", Audience: []string{"app"}},
+						},
+						CORS:       config.APIGatewayCORS{AllowOrigins: []string{"*"}},
+						Throttling: config.APIGatewayThrottling{BurstLimit: 100, RateLimit: 50},
+						Stages:     []config.APIGatewayStage{{Name: "v1"}},
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "my-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "my-lambda lambda",
+								Verb:        "POST",
+								Path:        "/examples",
+								APIGatewayRouteSettings: config.APIGatewayRouteSettings{
+									Authorizer: "cognito",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Lambda served by several API Gateways",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{apiGatewayResource, getAPIGatewayResource, lambdaResource},
+					Relationships: []resources.Relationship{
+						{Source: apiGatewayResource, Target: lambdaResource},
+						{Source: getAPIGatewayResource, Target: lambdaResource},
+					},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "my-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "my-lambda lambda",
+								Verb:        "POST",
+								Path:        "/examples",
+								Routes:      []config.APIGatewayRoute{{Verb: "GET", Path: "/examples"}},
+							},
+						},
+					},
+					{StackName: "my-stack", APIG: true},
+				},
+			},
+		},
+		{
+			name: "API Gateway integrations with a queue, a stream and a restful API",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{
+						apiGatewayResource, getAPIGatewayResource, sqsResource, kinesisResource, restfulAPIResource,
+					},
+					Relationships: []resources.Relationship{
+						{Source: apiGatewayResource, Target: sqsResource},
+						{Source: getAPIGatewayResource, Target: sqsResource},
+						{Source: apiGatewayResource, Target: kinesisResource},
+						{Source: getAPIGatewayResource, Target: restfulAPIResource},
+					},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Integrations: []config.APIGatewayIntegration{
+							{
+								Name:   "myQueueIntegration",
+								Type:   "SQS",
+								Target: "my-queue",
+								Routes: []config.APIGatewayRoute{
+									{Verb: "POST", Path: "/examples"},
+									{Verb: "GET", Path: "/examples"},
+								},
+							},
+							{
+								Name:   "myStreamIntegration",
+								Type:   "KINESIS",
+								Target: "my-stream",
+								Routes: []config.APIGatewayRoute{{Verb: "POST", Path: "/examples"}},
+							},
+						},
+					},
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Integrations: []config.APIGatewayIntegration{
+							{
+								Name:   "myApiIntegration",
+								Type:   "HTTP",
+								Target: "var.my_api_api_base_url",
+								Routes: []config.APIGatewayRoute{{Verb: "GET", Path: "/examples"}},
+							},
+						},
+					},
+				},
+				Kinesis:     []config.Kinesis{{Name: "my-stream", RetentionPeriod: "24"}},
+				SQSs:        []config.SQS{myQueueSQS},
+				RestfulAPIs: []config.RestfulAPI{{Name: "my-api"}},
+			},
+		},
+		{
+			name: "Lambda served by the routes of a WebSocket API",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{connectResource, sendMessageResource, lambdaResource},
+					Relationships: []resources.Relationship{
+						{Source: connectResource, Target: lambdaResource},
+						{Source: sendMessageResource, Target: lambdaResource},
+					},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName:    "my-stack",
+						APIG:         true,
+						ProtocolType: "WEBSOCKET",
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "my-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "my-lambda lambda",
+								Path:        "$connect",
+								Routes:      []config.APIGatewayRoute{{Path: "sendMessage"}},
+							},
+						},
+					},
+					{StackName: "my-stack", APIG: true, ProtocolType: "WEBSOCKET"},
+				},
+			},
+		},
 	}
 
 	for i := range tests {
@@ -114,8 +302,9 @@ func TestTransformDrawIOToYAML_Database(t *testing.T) {
 		resources  *resources.ResourceCollection
 	}
 
-	database := resources.NewGenericResource("id1", "my-database", awsresources.DatabaseType.String())
+	database := resources.NewGenericResource("id1", "my-database", awsresources.DynamoDBType.String())
 	lambda := resources.NewGenericResource("id2", "myReceiver", awsresources.LambdaType.String())
+	documentDB := resources.NewGenericResource("id3", "my-docdb", awsresources.DatabaseType.String())
 
 	tests := []struct {
 		name      string
@@ -129,7 +318,9 @@ func TestTransformDrawIOToYAML_Database(t *testing.T) {
 				yamlConfig: diagramConfig,
 				resources:  &resources.ResourceCollection{Resources: []resources.Resource{database}},
 			},
-			want: &config.Config{},
+			want: &config.Config{
+				DynamoDBs: []config.DynamoDB{{Name: "my-database", BillingMode: "PAY_PER_REQUEST", HashKey: "id"}},
+			},
 		},
 		{
 			name: "database receives data from a Lambda",
@@ -154,6 +345,40 @@ func TestTransformDrawIOToYAML_Database(t *testing.T) {
 						},
 					},
 				},
+				DynamoDBs: []config.DynamoDB{{Name: "my-database", BillingMode: "PAY_PER_REQUEST", HashKey: "id"}},
+			},
+		},
+		{
+			name: "DocumentDB is not a DynamoDB table",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources:  &resources.ResourceCollection{Resources: []resources.Resource{documentDB}},
+			},
+			want: &config.Config{},
+		},
+		{
+			name: "DocumentDB receives data from a Lambda",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources:     []resources.Resource{documentDB, lambda},
+					Relationships: []resources.Relationship{{Source: lambda, Target: documentDB}},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "myReceiver",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "myReceiver lambda",
+						Envars: map[string]string{
+							"MY_DOCDB_DB_HOST":            "var.my_docdb_db_host",
+							"MY_DOCDB_DB_USER":            "var.my_docdb_db_user",
+							"MY_DOCDB_DB_PASSWORD_SECRET": "var.my_docdb_db_password_secret",
+						},
+					},
+				},
 			},
 		},
 	}
@@ -438,7 +663,7 @@ func TestTransformDrawIOToYAML_Lambda(t *testing.T) {
 						SQSTriggers: []config.SQSTrigger{{SourceARN: "aws_sqs_queue.my_queue_sqs.arn"}},
 					},
 				},
-				SQSs: []config.SQS{{Name: "my-queue", MaxReceiveCount: 10}},
+				SQSs: []config.SQS{myQueueSQS},
 			},
 		},
 		{
@@ -463,9 +688,9 @@ func TestTransformDrawIOToYAML_Lambda(t *testing.T) {
 					},
 				},
 				SNSs: []config.SNS{{
-					Name:       "my-notification",
-					BucketName: "my-bucket",
-					Lambdas:    []config.SNSResource{{Name: "myReceiver", Events: []string{"s3:ObjectCreated:*"}}},
+					Name:          "my-notification",
+					BucketName:    "my-bucket",
+					Subscriptions: []config.SNSSubscription{{Protocol: "lambda", Endpoint: "myReceiver"}},
 				}},
 				Buckets: []config.S3{{Name: "my-bucket", ExpirationDays: 90}},
 			},
@@ -580,7 +805,7 @@ func TestTransformDrawIOToYAML_SQS(t *testing.T) {
 				},
 			},
 			want: &config.Config{
-				SQSs: []config.SQS{{Name: "my-queue", MaxReceiveCount: 10}},
+				SQSs: []config.SQS{myQueueSQS},
 			},
 		},
 		{
@@ -602,7 +827,7 @@ func TestTransformDrawIOToYAML_SQS(t *testing.T) {
 						Envars:      map[string]string{"MY_QUEUE_SQS_QUEUE_URL": "aws_sqs_queue.my_queue_sqs.name"},
 					},
 				},
-				SQSs: []config.SQS{{Name: "my-queue", MaxReceiveCount: 10}},
+				SQSs: []config.SQS{myQueueSQS},
 			},
 		},
 		{
@@ -618,11 +843,11 @@ func TestTransformDrawIOToYAML_SQS(t *testing.T) {
 				},
 			},
 			want: &config.Config{
-				SQSs: []config.SQS{{Name: "my-queue", MaxReceiveCount: 10}},
+				SQSs: []config.SQS{myQueueSQS},
 				SNSs: []config.SNS{{
-					Name:       "my-notification",
-					BucketName: "my-bucket",
-					SQSs:       []config.SNSResource{{Name: "my-queue", Events: []string{"s3:ObjectCreated:*"}}},
+					Name:          "my-notification",
+					BucketName:    "my-bucket",
+					Subscriptions: []config.SNSSubscription{{Protocol: "sqs", Endpoint: "my-queue"}},
 				}},
 				Buckets: []config.S3{{Name: "my-bucket", ExpirationDays: 90}},
 			},
@@ -703,6 +928,87 @@ func TestTransformDrawIOToYAML_SNS(t *testing.T) {
 	}
 }
 
+func TestTransformDrawIOToYAML_EventBridge(t *testing.T) {
+	type args struct {
+		yamlConfig *config.Config
+		resources  *resources.ResourceCollection
+	}
+
+	eventBridge := resources.NewGenericResource("id1", "orders", awsresources.EventBridgeType.String())
+	lambda := resources.NewGenericResource("id2", "processOrder", awsresources.LambdaType.String())
+	sqs := resources.NewGenericResource("id3", "my-queue", awsresources.SQSType.String())
+	sns := resources.NewGenericResource("id4", "order-events", awsresources.SNSType.String())
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *config.Config
+		targetErr error
+	}{
+		{
+			name: "only EventBridge",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources:  &resources.ResourceCollection{Resources: []resources.Resource{eventBridge}},
+			},
+			want: &config.Config{EventBridges: []config.EventBridge{{Name: "orders"}}},
+		},
+		{
+			name: "EventBridge sends events to a Lambda, an SQS queue and an SNS topic",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{eventBridge, lambda, sqs, sns},
+					Relationships: []resources.Relationship{
+						{Source: eventBridge, Target: lambda},
+						{Source: eventBridge, Target: sqs},
+						{Source: eventBridge, Target: sns},
+					},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "processOrder",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "processOrder lambda",
+					},
+				},
+				EventBridges: []config.EventBridge{{
+					Name: "orders",
+					Rules: []config.EventBridgeRule{{
+						Name:         "ordersRule",
+						EventPattern: `{"source":["orders"]}`,
+						Targets: []config.EventBridgeTarget{
+							{Type: config.EventBridgeTargetLambda, Target: "processOrder"},
+							{Type: config.EventBridgeTargetSQS, Target: "my-queue"},
+							{Type: config.EventBridgeTargetSNS, Target: "order-events"},
+						},
+					}},
+				}},
+				SNSs: []config.SNS{{Name: "order-events"}},
+				SQSs: []config.SQS{myQueueSQS},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewTransformer(tc.args.yamlConfig, tc.args.resources).Transform()
+
+			if tc.targetErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			} else {
+				require.ErrorIs(t, err, tc.targetErr)
+			}
+		})
+	}
+}
+
 func TestTransformDrawIOToYAML_RestfulAPI(t *testing.T) {
 	type args struct {
 		yamlConfig *config.Config
@@ -24,19 +24,24 @@ type Transformer struct {
 
 	apiGatewayResourcesByName map[string]resources.Resource
 	dbResourcesByName         map[string]resources.Resource
+	eventBusResourcesByName   map[string]resources.Resource
 	googleBQResourcesByName   map[string]resources.Resource
 	kinesisResourcesByName    map[string]resources.Resource
 	lambdaResourcesByName     map[string]resources.Resource
 	restfulAPIResourcesByName map[string]resources.Resource
 	s3BucketResourcesByName   map[string]resources.Resource
+	snsResourcesByName        map[string]resources.Resource
 	sqsResourcesByName        map[string]resources.Resource
 
-	cronResourcesByLabel     map[string]resources.Resource
-	endpointResourcesByLabel map[string]resources.Resource
-	kinesisResourcesByLabel  map[string]resources.Resource
-	lambdaResourcesByLabel   map[string]resources.Resource
-	s3BucketResourcesByLabel map[string]resources.Resource
-	sqsResourcesByLabel      map[string]resources.Resource
+	cronResourcesByLabel      map[string]resources.Resource
+	endpointResourcesByLabel  map[string]resources.Resource
+	eventBusResourcesByLabel  map[string]resources.Resource
+	eventRuleResourcesByLabel map[string]resources.Resource
+	kinesisResourcesByLabel   map[string]resources.Resource
+	lambdaResourcesByLabel    map[string]resources.Resource
+	s3BucketResourcesByLabel  map[string]resources.Resource
+	snsResourcesByLabel       map[string]resources.Resource
+	sqsResourcesByLabel       map[string]resources.Resource
 
 	apigIntegrationRouteMap map[awsresources.ResourceARN][]awsresources.ResourceARN
 	resourceAPIGIntegration map[awsresources.ResourceARN]awsresources.ResourceARN
@@ -56,19 +61,24 @@ func NewTransformer(yamlConfig *config.Config, tfConfig *hcl.Config) *Transforme
 
 		apiGatewayResourcesByName: map[string]resources.Resource{},
 		dbResourcesByName:         map[string]resources.Resource{},
+		eventBusResourcesByName:   map[string]resources.Resource{},
 		googleBQResourcesByName:   map[string]resources.Resource{},
 		kinesisResourcesByName:    map[string]resources.Resource{},
 		lambdaResourcesByName:     map[string]resources.Resource{},
 		restfulAPIResourcesByName: map[string]resources.Resource{},
 		s3BucketResourcesByName:   map[string]resources.Resource{},
+		snsResourcesByName:        map[string]resources.Resource{},
 		sqsResourcesByName:        map[string]resources.Resource{},
 
-		cronResourcesByLabel:     map[string]resources.Resource{},
-		endpointResourcesByLabel: map[string]resources.Resource{},
-		kinesisResourcesByLabel:  map[string]resources.Resource{},
-		lambdaResourcesByLabel:   map[string]resources.Resource{},
-		s3BucketResourcesByLabel: map[string]resources.Resource{},
-		sqsResourcesByLabel:      map[string]resources.Resource{},
+		cronResourcesByLabel:      map[string]resources.Resource{},
+		endpointResourcesByLabel:  map[string]resources.Resource{},
+		eventBusResourcesByLabel:  map[string]resources.Resource{},
+		eventRuleResourcesByLabel: map[string]resources.Resource{},
+		kinesisResourcesByLabel:   map[string]resources.Resource{},
+		lambdaResourcesByLabel:    map[string]resources.Resource{},
+		s3BucketResourcesByLabel:  map[string]resources.Resource{},
+		snsResourcesByLabel:       map[string]resources.Resource{},
+		sqsResourcesByLabel:       map[string]resources.Resource{},
 
 		apigIntegrationRouteMap: map[awsresources.ResourceARN][]awsresources.ResourceARN{},
 		resourceAPIGIntegration: map[awsresources.ResourceARN]awsresources.ResourceARN{},
@@ -151,8 +161,14 @@ func (t *Transformer) getResourceByARN(arn awsresources.ResourceARN) (resource r
 		resource = t.apiGatewayResourcesByName[arn.Name]
 	case awsresources.LabelAWSCron:
 		resource = t.cronResourcesByLabel[arn.Label]
+		if resource == nil {
+			// The rules matching an event pattern are drawn as their event bus.
+			resource = t.eventRuleResourcesByLabel[arn.Label]
+		}
 	case awsresources.LabelAWSEndpoint:
 		resource = t.endpointResourcesByLabel[arn.Label]
+	case awsresources.LabelAWSEventBus:
+		resource = t.eventBusResourcesByLabel[arn.Label]
 	case awsresources.LabelAWSKinesisStream:
 		if arn.Label == "" {
 			resource = t.kinesisResourcesByName[arn.Name]
@@ -171,6 +187,12 @@ func (t *Transformer) getResourceByARN(arn awsresources.ResourceARN) (resource r
 		} else {
 			resource = t.s3BucketResourcesByLabel[arn.Label]
 		}
+	case awsresources.LabelAWSSNSTopic:
+		if arn.Label == "" {
+			resource = t.snsResourcesByName[arn.Name]
+		} else {
+			resource = t.snsResourcesByLabel[arn.Label]
+		}
 	case awsresources.LabelAWSSQSQueue:
 		if arn.Label == "" {
 			resource = t.sqsResourcesByName[arn.Name]
@@ -249,6 +271,8 @@ func (t *Transformer) processTerraformResources() {
 				t.processCronResource(tfResourceConf)
 			case awsresources.LabelAWSEndpoint:
 				t.processEndpointResource(tfResourceConf)
+			case awsresources.LabelAWSEventBus:
+				t.processEventBusResource(tfResourceConf)
 			case awsresources.LabelAWSKinesisStream:
 				t.processKinesisResource(tfResourceConf)
 			case awsresources.LabelAWSLambdaEventSourceMapping:
@@ -257,6 +281,12 @@ func (t *Transformer) processTerraformResources() {
 				t.processLambdaResource(tfResourceConf)
 			case awsresources.LabelAWSS3Bucket:
 				t.processS3BucketResource(tfResourceConf)
+			case awsresources.LabelAWSScheduler:
+				// The target of a schedule is a nested block, which the parser does not expose, so the schedule is
+				// drawn without its Lambda.
+				t.processCronResource(tfResourceConf)
+			case awsresources.LabelAWSSNSTopic:
+				t.processSNSResource(tfResourceConf)
 			case awsresources.LabelAWSSQSQueue:
 				t.processSQSResource(tfResourceConf)
 			}
@@ -303,19 +333,54 @@ func (t *Transformer) processAPIGatewayIntegration(conf *hcl.Resource) {
 		t.yamlConfig.Draw.ReplaceableTexts)
 	apiIDARN := awsresources.ParseResourceARN(apiIDValue, awsresources.EndpointType)
 
-	integrationURIValue := replaceVars(conf.Attributes["integration_uri"].(string), t.tfConfig.Variables,
-		t.tfConfig.Locals, t.yamlConfig.Draw.ReplaceableTexts)
-	integrationURIARN := awsresources.ParseResourceARN(integrationURIValue, awsresources.LambdaType)
+	integrationURI, targetType := apiGatewayIntegrationTarget(conf.Attributes)
+	if integrationURI == "" {
+		return
+	}
+
+	integrationURIValue := replaceVars(integrationURI, t.tfConfig.Variables, t.tfConfig.Locals,
+		t.yamlConfig.Draw.ReplaceableTexts)
+	integrationURIARN := awsresources.ParseResourceARN(integrationURIValue, targetType)
 
 	t.relationshipsMap[apiIDARN] = append(t.relationshipsMap[apiIDARN], integrationURIARN)
 	t.resourceAPIGIntegration[integrationURIARN] = integrationARN
 }
 
+// apiGatewayIntegrationTarget returns the value that identifies the resource behind an API Gateway integration.
+// Lambda integrations use integration_uri; SQS and Kinesis integrations reference their target through the
+// request parameters. HTTP proxy integrations point outside the stack and have no target.
+func apiGatewayIntegrationTarget(attributes map[string]any) (string, awsresources.ResourceType) {
+	if integrationType, _ := attributes["integration_type"].(string); integrationType == "HTTP_PROXY" {
+		return "", awsresources.UnknownType
+	}
+
+	if uri, ok := attributes["integration_uri"].(string); ok {
+		return uri, awsresources.LambdaType
+	}
+
+	params, _ := attributes["request_parameters"].(map[string]any)
+
+	if queueURL, ok := params["QueueUrl"].(string); ok {
+		return queueURL, awsresources.SQSType
+	}
+
+	if streamName, ok := params["StreamName"].(string); ok {
+		return streamName, awsresources.KinesisType
+	}
+
+	return "", awsresources.UnknownType
+}
+
 func (t *Transformer) processCloudwatchEventTarget(conf *hcl.Resource) {
 	t.processResourceRelationships(conf, "rule", "arn", awsresources.CronType, awsresources.LambdaType)
 }
 
 func (t *Transformer) processCronResource(conf *hcl.Resource) {
+	if _, ok := conf.Attributes["event_pattern"]; ok {
+		t.processEventRuleResource(conf)
+		return
+	}
+
 	value, ok := conf.Attributes["schedule_expression"]
 	if !ok {
 		fmtcolor.Yellow.Printf("it is not cron: %s\n", conf.Labels)
@@ -355,6 +420,62 @@ func (t *Transformer) processEndpointResource(conf *hcl.Resource) {
 	}
 }
 
+// processEventBusResource adds the event bus, unless one of its rules has already added it.
+func (t *Transformer) processEventBusResource(conf *hcl.Resource) {
+	t.eventBusResourcesByLabel[conf.Labels[1]] = t.processEventBus(t.eventBusName(conf))
+}
+
+// processEventRuleResource draws the rule matching an event pattern as its event bus, so that the targets of the rule
+// are linked to the bus. The rules without event_bus_name belong to the default event bus.
+func (t *Transformer) processEventRuleResource(conf *hcl.Resource) {
+	busName := config.EventBridgeDefaultBus
+
+	if value, ok := conf.Attributes["event_bus_name"].(string); ok {
+		busName = t.eventBusName(conf)
+
+		// A reference to a bus of the stack is replaced by the name of that bus.
+		if busARN := awsresources.ParseResourceARN(value, awsresources.EventBridgeType); busARN.Label != "" {
+			busName = busARN.Label
+
+			for _, res := range t.tfConfig.Resources {
+				if len(res.Labels) == 2 && res.Labels[0] == busARN.Type && res.Labels[1] == busARN.Label {
+					busName = t.eventBusName(res)
+					break
+				}
+			}
+		}
+	}
+
+	t.eventRuleResourcesByLabel[conf.Labels[1]] = t.processEventBus(busName)
+}
+
+func (t *Transformer) processEventBus(name string) resources.Resource {
+	resource, ok := t.eventBusResourcesByName[name]
+	if !ok {
+		resource = resources.NewGenericResource(fmt.Sprintf("%d", t.id), name, awsresources.EventBridgeType.String())
+		t.id++
+
+		t.resources = append(t.resources, resource)
+		t.eventBusResourcesByName[name] = resource
+	}
+
+	return resource
+}
+
+// eventBusName returns the name of the event bus, given by the name attribute of the bus or the event_bus_name
+// attribute of a rule.
+func (t *Transformer) eventBusName(conf *hcl.Resource) string {
+	attributeName := "name"
+	if conf.Labels[0] == awsresources.LabelAWSCron {
+		attributeName = "event_bus_name"
+	}
+
+	value, _ := conf.Attributes[attributeName].(string)
+	value = replaceVars(value, t.tfConfig.Variables, t.tfConfig.Locals, t.yamlConfig.Draw.ReplaceableTexts)
+
+	return awsresources.ParseResourceARN(value, awsresources.EventBridgeType).Name
+}
+
 func (t *Transformer) processEventSourceMapping(conf *hcl.Resource) {
 	t.processResourceRelationships(conf, "event_source_arn", "function_name",
 		awsresources.UnknownType, awsresources.LambdaType)
@@ -497,6 +618,10 @@ func (t *Transformer) processS3BucketResource(conf *hcl.Resource) {
 	t.processResource(conf, awsresources.S3Type, "bucket", t.s3BucketResourcesByName, t.s3BucketResourcesByLabel)
 }
 
+func (t *Transformer) processSNSResource(conf *hcl.Resource) {
+	t.processResource(conf, awsresources.SNSType, "name", t.snsResourcesByName, t.snsResourcesByLabel)
+}
+
 func (t *Transformer) processSQSResource(conf *hcl.Resource) {
 	t.processResource(conf, awsresources.SQSType, "name", t.sqsResourcesByName, t.sqsResourcesByLabel)
 }
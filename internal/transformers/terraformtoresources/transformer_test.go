@@ -107,6 +107,30 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "EventBridge Scheduler schedule",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_scheduler_schedule",
+							Name:   "example_receiver_schedule",
+							Labels: []string{"aws_scheduler_schedule", "example_receiver_schedule"},
+							Attributes: map[string]any{
+								"schedule_expression":          "at(2030-06-30T18:00:00)",
+								"schedule_expression_timezone": "Europe/Amsterdam",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{resources.NewGenericResource("1", "at(2030-06-30T18:00:00)",
+					awsresources.CronType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
 		{
 			name: "cron",
 			fields: fields{
@@ -272,6 +296,29 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "sns",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_sns_topic",
+							Name:   "my_topic_sns",
+							Labels: []string{"aws_sns_topic", "my_topic_sns"},
+							Attributes: map[string]any{
+								"name": "my-topic",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "my-topic", awsresources.SNSType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
 		{
 			name: "sqs",
 			fields: fields{
@@ -750,6 +797,137 @@ func TestTransformer_TransformFromCronToResource(t *testing.T) {
 	}
 }
 
+func TestTransformer_TransformFromEventBridgeToResource(t *testing.T) {
+	type fields struct {
+		yamlConfig *config.Config
+		tfConfig   *hcl.Config
+	}
+
+	lambdaResource := resources.NewGenericResource("1", "myReceiver", awsresources.LambdaType.String())
+	busResource := resources.NewGenericResource("2", "orders", awsresources.EventBridgeType.String())
+	sqsResource := resources.NewGenericResource("3", "orders-queue", awsresources.SQSType.String())
+	snsResource := resources.NewGenericResource("4", "order-events", awsresources.SNSType.String())
+	defaultBusResource := resources.NewGenericResource("5", "default", awsresources.EventBridgeType.String())
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *resources.ResourceCollection
+	}{
+		{
+			name: "from event bus to targets",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Modules: []*hcl.Module{
+						{
+							Labels: []string{"my_receiving_lambda"},
+							Attributes: map[string]any{
+								"function_name": "myReceiver",
+							},
+						},
+					},
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_cloudwatch_event_rule",
+							Name:   "orders_order_created_rule",
+							Labels: []string{"aws_cloudwatch_event_rule", "orders_order_created_rule"},
+							Attributes: map[string]any{
+								"event_bus_name": "aws_cloudwatch_event_bus.orders_event_bus.name",
+								"event_pattern":  `{"source":["orders"]}`,
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_bus",
+							Name:   "orders_event_bus",
+							Labels: []string{"aws_cloudwatch_event_bus", "orders_event_bus"},
+							Attributes: map[string]any{
+								"name": "orders",
+							},
+						},
+						{
+							Type:   "aws_sqs_queue",
+							Name:   "orders_queue_sqs",
+							Labels: []string{"aws_sqs_queue", "orders_queue_sqs"},
+							Attributes: map[string]any{
+								"name": "orders-queue",
+							},
+						},
+						{
+							Type:   "aws_sns_topic",
+							Name:   "order_events_sns",
+							Labels: []string{"aws_sns_topic", "order_events_sns"},
+							Attributes: map[string]any{
+								"name": "order-events",
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_rule",
+							Name:   "default_instance_state_changed_rule",
+							Labels: []string{"aws_cloudwatch_event_rule", "default_instance_state_changed_rule"},
+							Attributes: map[string]any{
+								"event_pattern": `{"source":["aws.ec2"]}`,
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_target",
+							Name:   "orders_order_created_rule_to_orders_queue_sqs",
+							Labels: []string{"aws_cloudwatch_event_target", "orders_order_created_rule_to_orders_queue_sqs"},
+							Attributes: map[string]any{
+								"rule": "aws_cloudwatch_event_rule.orders_order_created_rule.name",
+								"arn":  "aws_sqs_queue.orders_queue_sqs.arn",
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_target",
+							Name:   "orders_order_created_rule_to_order_events_sns",
+							Labels: []string{"aws_cloudwatch_event_target", "orders_order_created_rule_to_order_events_sns"},
+							Attributes: map[string]any{
+								"rule": "aws_cloudwatch_event_rule.orders_order_created_rule.name",
+								"arn":  "aws_sns_topic.order_events_sns.arn",
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_target",
+							Name:   "default_instance_state_changed_rule_to_my_receiving_lambda",
+							Labels: []string{"aws_cloudwatch_event_target", "default_instance_state_changed_rule_to_lambda"},
+							Attributes: map[string]any{
+								"rule": "aws_cloudwatch_event_rule.default_instance_state_changed_rule.name",
+								"arn":  "module.my_receiving_lambda.function_arn",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					lambdaResource, busResource, sqsResource, snsResource, defaultBusResource},
+				Relationships: []resources.Relationship{
+					{Source: busResource, Target: sqsResource},
+					{Source: busResource, Target: snsResource},
+					{Source: defaultBusResource, Target: lambdaResource},
+				},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tr := NewTransformer(
+				tc.fields.yamlConfig,
+				tc.fields.tfConfig,
+			)
+
+			got := tr.Transform()
+
+			require.ElementsMatch(t, tc.want.Resources, got.Resources)
+			require.ElementsMatch(t, tc.want.Relationships, got.Relationships)
+		})
+	}
+}
+
 func TestTransformer_TransformEndpointAPIGatewayLambda(t *testing.T) {
 	type fields struct {
 		yamlConfig *config.Config
@@ -20,40 +20,62 @@ var ErrEmptyConfig = errors.New("config file is empty")
 
 type Transformer struct {
 	yamlConfig *config.Config
-
-	apigatewayByName map[string]resources.Resource
-	cronByName       map[string]resources.Resource
-	databaseByName   map[string]resources.Resource
-	endpointByName   map[string]resources.Resource
-	googleBQByName   map[string]resources.Resource
-	kinesisByName    map[string]resources.Resource
-	lambdaByName     map[string]resources.Resource
-	restfulAPIByName map[string]resources.Resource
-	s3BucketByName   map[string]resources.Resource
-	snsByName        map[string]resources.Resource
-	sqsByName        map[string]resources.Resource
+	quiet      bool
+
+	apigatewayByName   map[string]resources.Resource
+	cronByName         map[string]resources.Resource
+	databaseByName     map[string]resources.Resource
+	endpointByName     map[string]resources.Resource
+	eventBridgeByName  map[string]resources.Resource
+	googleBQByName     map[string]resources.Resource
+	kinesisByName      map[string]resources.Resource
+	lambdaByName       map[string]resources.Resource
+	restfulAPIByName   map[string]resources.Resource
+	s3BucketByName     map[string]resources.Resource
+	snsByName          map[string]resources.Resource
+	sqsByName          map[string]resources.Resource
+	webSocketAPIByName map[string]resources.Resource
 
 	relationshipsMap map[awsresources.ResourceARN][]awsresources.ResourceARN
 }
 
-func NewTransformer(yamlConfig *config.Config) *Transformer {
-	return &Transformer{
+// Option is a functional option to configure the transformer.
+type Option func(*Transformer)
+
+// WithoutWarnings stops the transformer from printing the environment variables and integration targets it cannot
+// identify, for the callers that only need the resources it can.
+func WithoutWarnings() Option {
+	return func(t *Transformer) {
+		t.quiet = true
+	}
+}
+
+func NewTransformer(yamlConfig *config.Config, opts ...Option) *Transformer {
+	t := &Transformer{
 		yamlConfig: yamlConfig,
 
-		apigatewayByName: map[string]resources.Resource{},
-		cronByName:       map[string]resources.Resource{},
-		databaseByName:   map[string]resources.Resource{},
-		endpointByName:   map[string]resources.Resource{},
-		googleBQByName:   map[string]resources.Resource{},
-		kinesisByName:    map[string]resources.Resource{},
-		lambdaByName:     map[string]resources.Resource{},
-		restfulAPIByName: map[string]resources.Resource{},
-		s3BucketByName:   map[string]resources.Resource{},
-		snsByName:        map[string]resources.Resource{},
-		sqsByName:        map[string]resources.Resource{},
+		apigatewayByName:   map[string]resources.Resource{},
+		cronByName:         map[string]resources.Resource{},
+		databaseByName:     map[string]resources.Resource{},
+		endpointByName:     map[string]resources.Resource{},
+		eventBridgeByName:  map[string]resources.Resource{},
+		googleBQByName:     map[string]resources.Resource{},
+		kinesisByName:      map[string]resources.Resource{},
+		lambdaByName:       map[string]resources.Resource{},
+		restfulAPIByName:   map[string]resources.Resource{},
+		s3BucketByName:     map[string]resources.Resource{},
+		snsByName:          map[string]resources.Resource{},
+		sqsByName:          map[string]resources.Resource{},
+		webSocketAPIByName: map[string]resources.Resource{},
 
 		relationshipsMap: map[awsresources.ResourceARN][]awsresources.ResourceARN{},
 	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
 func (t *Transformer) Transform() (*resources.ResourceCollection, error) {
@@ -72,6 +94,7 @@ func (t *Transformer) Transform() (*resources.ResourceCollection, error) {
 	t.extractS3BucketResources(&rscs, &id)
 	t.extractSNSBucketResources(&rscs, &id)
 	t.extractSQSResources(&rscs, &id)
+	t.transformEventBridges(&rscs, &relationships, &id)
 
 	t.buildRelationships(&relationships)
 
@@ -197,10 +220,65 @@ func (t *Transformer) extractSQSResources(rscs *[]resources.Resource, id *int) {
 	t.extractResourcesByType(configResources, awsresources.SQSType, t.sqsByName, rscs, id)
 }
 
+// transformEventBridges links each event bus to the resources of the config targeted by its rules. The targets given by
+// ARN or Terraform expression are managed elsewhere and are not drawn.
+func (t *Transformer) transformEventBridges(
+	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
+) {
+	for i := range t.yamlConfig.EventBridges {
+		res := t.yamlConfig.EventBridges[i]
+
+		busRes, ok := t.eventBridgeByName[res.Name]
+		if !ok {
+			busRes = resources.NewGenericResource(fmt.Sprintf("%d", *id), res.Name,
+				awsresources.EventBridgeType.String())
+			*id++
+
+			*rscs = append(*rscs, busRes)
+
+			t.eventBridgeByName[res.Name] = busRes
+		}
+
+		for _, rule := range res.Rules {
+			for _, target := range rule.Targets {
+				if config.IsReference(target.Target) {
+					continue
+				}
+
+				var targetRes resources.Resource
+
+				switch target.Type {
+				case config.EventBridgeTargetLambda:
+					targetRes = t.lambdaByName[awsresources.ToLambdaCase(target.Target)]
+				case config.EventBridgeTargetKinesis:
+					targetRes = t.kinesisByName[fmt.Sprintf("%s_%s", strcase.ToSnake(target.Target),
+						awsresources.SuffixByResource[awsresources.KinesisType])]
+				case config.EventBridgeTargetSNS:
+					targetRes = t.snsByName[target.Target]
+				case config.EventBridgeTargetSQS:
+					targetRes = t.sqsByName[fmt.Sprintf("%s_%s", strcase.ToSnake(target.Target),
+						awsresources.SuffixByResource[awsresources.SQSType])]
+				}
+
+				if targetRes == nil {
+					continue
+				}
+
+				*relationships = append(*relationships, resources.Relationship{Source: busRes, Target: targetRes})
+			}
+		}
+	}
+}
+
 func (t *Transformer) transformAPIGateways(
 	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
 ) {
 	for _, res := range t.yamlConfig.APIGateways {
+		if res.IsWebSocket() {
+			t.transformWebSocketAPI(&res, rscs, relationships, id)
+			continue
+		}
+
 		endpointValue := res.APIDomain
 
 		endpointRes, ok := t.endpointByName[endpointValue]
@@ -215,35 +293,145 @@ func (t *Transformer) transformAPIGateways(
 
 		for i := range res.Lambdas {
 			l := res.Lambdas[i]
-			apigValue := fmt.Sprintf("%s %s", l.Verb, l.Path)
 
-			apigRes, ok := t.apigatewayByName[apigValue]
+			routes := l.GetRoutes()
+
+			apigResources := make([]resources.Resource, 0, len(routes))
+			for _, route := range routes {
+				apigResources = append(apigResources, t.transformAPIGatewayRoute(route.RouteKey(), rscs, id))
+			}
+
+			lambdaName := awsresources.ToLambdaCase(l.Name)
+
+			t.transformLambda(&config.Lambda{Name: lambdaName, Envars: l.Envars}, rscs, relationships, id)
+
+			for _, apigRes := range apigResources {
+				*relationships = append(*relationships,
+					resources.Relationship{
+						Source: apigRes,
+						Target: t.lambdaByName[lambdaName],
+					}, resources.Relationship{
+						Source: endpointRes,
+						Target: apigRes,
+					})
+			}
+		}
+
+		for i := range res.Integrations {
+			t.transformAPIGatewayIntegration(&res.Integrations[i], endpointRes, rscs, relationships, id)
+		}
+	}
+}
+
+// transformWebSocketAPI adds a WebSocket API node per route, connected to the Lambda serving it. WebSocket APIs
+// have no custom domain, so no endpoint is added.
+func (t *Transformer) transformWebSocketAPI(
+	res *config.APIGateway, rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
+) {
+	for i := range res.Lambdas {
+		l := res.Lambdas[i]
 
+		routes := l.GetRoutes()
+
+		wsResources := make([]resources.Resource, 0, len(routes))
+		for _, route := range routes {
+			routeKey := route.RouteKey()
+
+			wsRes, ok := t.webSocketAPIByName[routeKey]
 			if !ok {
-				apigRes = resources.NewGenericResource(
-					fmt.Sprintf("%d", *id), apigValue, awsresources.APIGatewayType.String())
-				*rscs = append(*rscs, apigRes)
+				wsRes = resources.NewGenericResource(
+					fmt.Sprintf("%d", *id), routeKey, awsresources.WebSocketAPIType.String())
+				*rscs = append(*rscs, wsRes)
 				*id++
 
-				t.apigatewayByName[apigValue] = apigRes
+				t.webSocketAPIByName[routeKey] = wsRes
 			}
 
-			lambdaName := awsresources.ToLambdaCase(l.Name)
+			wsResources = append(wsResources, wsRes)
+		}
 
-			t.transformLambda(&config.Lambda{Name: lambdaName, Envars: l.Envars}, rscs, relationships, id)
+		lambdaName := awsresources.ToLambdaCase(l.Name)
 
+		t.transformLambda(&config.Lambda{Name: lambdaName, Envars: l.Envars}, rscs, relationships, id)
+
+		for _, wsRes := range wsResources {
 			*relationships = append(*relationships,
-				resources.Relationship{
-					Source: apigRes,
-					Target: t.lambdaByName[lambdaName],
-				}, resources.Relationship{
-					Source: endpointRes,
-					Target: apigRes,
-				})
+				resources.Relationship{Source: wsRes, Target: t.lambdaByName[lambdaName]})
+		}
+	}
+}
+
+func (t *Transformer) transformAPIGatewayRoute(
+	routeKey string, rscs *[]resources.Resource, id *int,
+) resources.Resource {
+	apigRes, ok := t.apigatewayByName[routeKey]
+	if !ok {
+		apigRes = resources.NewGenericResource(
+			fmt.Sprintf("%d", *id), routeKey, awsresources.APIGatewayType.String())
+		*rscs = append(*rscs, apigRes)
+		*id++
+
+		t.apigatewayByName[routeKey] = apigRes
+	}
+
+	return apigRes
+}
+
+func (t *Transformer) transformAPIGatewayIntegration(
+	integration *config.APIGatewayIntegration, endpointRes resources.Resource, rscs *[]resources.Resource,
+	relationships *[]resources.Relationship, id *int,
+) {
+	var targetARN awsresources.ResourceARN
+
+	switch integration.Type {
+	case config.APIGatewayIntegrationKinesis:
+		targetARN = awsresources.ResourceARN{Type: awsresources.LabelAWSKinesisStream, Label: fmt.Sprintf("%s_%s",
+			strcase.ToSnake(integration.Target), awsresources.SuffixByResource[awsresources.KinesisType])}
+	case config.APIGatewayIntegrationSQS:
+		targetARN = awsresources.ResourceARN{Type: awsresources.LabelAWSSQSQueue, Label: fmt.Sprintf("%s_%s",
+			strcase.ToSnake(integration.Target), awsresources.SuffixByResource[awsresources.SQSType])}
+	}
+
+	for _, route := range integration.GetRoutes() {
+		routeKey := route.RouteKey()
+		apigRes := t.transformAPIGatewayRoute(routeKey, rscs, id)
+
+		*relationships = append(*relationships, resources.Relationship{Source: endpointRes, Target: apigRes})
+
+		switch integration.Type {
+		case config.APIGatewayIntegrationHTTP:
+			value, ok := t.restfulAPINameFromURL(integration.Target)
+			if !ok {
+				t.warn("yaml to resource: unidentified integration target: %s\n", integration.Target)
+				continue
+			}
+
+			t.fromLambdaToResource(value, apigRes, t.restfulAPIByName, id, awsresources.RestfulAPIType, rscs,
+				relationships)
+		default:
+			apigARN := awsresources.ResourceARN{Type: awsresources.LabelAWSAPIGatewayRoute, Name: routeKey}
+			t.relationshipsMap[apigARN] = append(t.relationshipsMap[apigARN], targetARN)
 		}
 	}
 }
 
+// restfulAPINameFromURL returns the name of the restful API whose base URL variable is the given target, falling back
+// to the name encoded in the variable when no restful API in the config matches it.
+func (t *Transformer) restfulAPINameFromURL(target string) (string, bool) {
+	variable := strings.TrimPrefix(target, "var.")
+
+	for i := range t.yamlConfig.RestfulAPIs {
+		name := t.yamlConfig.RestfulAPIs[i].Name
+		if strcase.ToSnake(name+awsresources.EnvarSuffixRestfulAPI) == variable {
+			return name, true
+		}
+	}
+
+	value, resType := t.getValueTypeFromEnvar(strings.ToUpper(variable), "")
+
+	return value, resType == awsresources.RestfulAPIType
+}
+
 func (t *Transformer) transformLambda(
 	res *config.Lambda, rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
 ) {
@@ -306,7 +494,7 @@ func (t *Transformer) transformLambdaEnvars(
 	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
 ) {
 	for k, v := range res.Envars {
-		value, resType := t.getValueTypeFromEnvar(k)
+		value, resType := t.getValueTypeFromEnvar(k, v)
 
 		switch resType {
 		case awsresources.DatabaseType:
@@ -325,34 +513,23 @@ func (t *Transformer) transformLambdaEnvars(
 		case awsresources.RestfulAPIType:
 			t.fromLambdaToResource(value, lambda, t.restfulAPIByName, id, resType, rscs, relationships)
 		default:
-			fmtcolor.Yellow.Printf("yaml to resource: unidentified variable: %s=%s\n", k, v)
+			t.warn("yaml to resource: unidentified variable: %s=%s\n", k, v)
 		}
 	}
 }
 
-func (*Transformer) getValueTypeFromEnvar(k string) (value string, resType awsresources.ResourceType) {
-	switch {
-	case strings.HasSuffix(k, awsresources.EnvarSuffixDBHost):
+// getValueTypeFromEnvar returns the type of the resource the environment variable refers to and, for the resources
+// found by the name of the variable alone, like the databases, their name.
+func (*Transformer) getValueTypeFromEnvar(k, v string) (value string, resType awsresources.ResourceType) {
+	resType = awsresources.EnvarResourceType(k, v)
+
+	switch resType {
+	case awsresources.DatabaseType:
 		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixDBHost, awsresources.ToDatabaseCase)
-		resType = awsresources.DatabaseType
-	case strings.HasSuffix(k, awsresources.EnvarSuffixGoogleBQ):
+	case awsresources.GoogleBQType:
 		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixGoogleBQ, awsresources.ToGoogleBQCase)
-		resType = awsresources.GoogleBQType
-	case strings.HasSuffix(k, awsresources.EnvarSuffixKinesisStreamURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixKinesisStreamURL, awsresources.ToKinesisCase)
-		resType = awsresources.KinesisType
-	case strings.HasSuffix(k, awsresources.EnvarSuffixS3BucketURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketURL, awsresources.ToS3BucketCase)
-		resType = awsresources.S3Type
-	case strings.HasSuffix(k, awsresources.EnvarSuffixS3BucketName):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketName, awsresources.ToS3BucketCase)
-		resType = awsresources.S3Type
-	case strings.HasSuffix(k, awsresources.EnvarSuffixSQSQueueURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixSQSQueueURL, awsresources.ToSQSCase)
-		resType = awsresources.SQSType
-	case strings.HasSuffix(k, awsresources.EnvarSuffixRestfulAPI):
+	case awsresources.RestfulAPIType:
 		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixRestfulAPI, awsresources.ToRestfulAPICase)
-		resType = awsresources.RestfulAPIType
 	}
 
 	return value, resType
@@ -374,3 +551,9 @@ func (t *Transformer) fromLambdaToResource(
 
 	*relationships = append(*relationships, resources.Relationship{Source: lambda, Target: r})
 }
+
+func (t *Transformer) warn(format string, args ...any) {
+	if !t.quiet {
+		fmtcolor.Yellow.Printf(format, args...)
+	}
+}
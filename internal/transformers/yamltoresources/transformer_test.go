@@ -29,6 +29,26 @@ var (
 	targetSQS        = resources.NewGenericResource("10", "target", awsresources.SQSType.String())
 	sourceSQS        = resources.NewGenericResource("11", "source", awsresources.SQSType.String())
 
+	routesEndpoint = resources.NewGenericResource("1", "api.domain.com", awsresources.EndpointType.String())
+	getItems       = resources.NewGenericResource("2", "GET /items", awsresources.APIGatewayType.String())
+	postItems      = resources.NewGenericResource("3", "POST /items", awsresources.APIGatewayType.String())
+	itemsLambda    = resources.NewGenericResource("4", "items", awsresources.LambdaType.String())
+	postOrders     = resources.NewGenericResource("5", "POST /orders", awsresources.APIGatewayType.String())
+	defaultRoute   = resources.NewGenericResource("6", "$default", awsresources.APIGatewayType.String())
+	legacyAPI      = resources.NewGenericResource("7", "legacy", awsresources.RestfulAPIType.String())
+	ordersSQS      = resources.NewGenericResource("8", "orders", awsresources.SQSType.String())
+
+	connectRoute     = resources.NewGenericResource("1", "$connect", awsresources.WebSocketAPIType.String())
+	connectLambda    = resources.NewGenericResource("2", "connect", awsresources.LambdaType.String())
+	sendMessageRoute = resources.NewGenericResource("3", "sendMessage", awsresources.WebSocketAPIType.String())
+	chatLambda       = resources.NewGenericResource("4", "chat", awsresources.LambdaType.String())
+
+	orderLambda     = resources.NewGenericResource("1", "processOrder", awsresources.LambdaType.String())
+	orderEventsSNS  = resources.NewGenericResource("2", "order-events", awsresources.SNSType.String())
+	orderQueueSQS   = resources.NewGenericResource("3", "order-queue", awsresources.SQSType.String())
+	ordersEventBus  = resources.NewGenericResource("4", "orders", awsresources.EventBridgeType.String())
+	defaultEventBus = resources.NewGenericResource("5", "default", awsresources.EventBridgeType.String())
+
 	wantResourceCollection = &resources.ResourceCollection{
 		Resources: []resources.Resource{
 			endpointResource,
@@ -117,6 +137,109 @@ func TestTransformer_Transform(t *testing.T) {
 			fields: fields{yamlConfig: diagramYAML},
 			want:   wantResourceCollection,
 		},
+		{
+			name: "API Gateway with several routes and integrations",
+			fields: fields{yamlConfig: &config.Config{
+				SQSs:        []config.SQS{{Name: "orders"}},
+				RestfulAPIs: []config.RestfulAPI{{Name: "legacy"}},
+				APIGateways: []config.APIGateway{{
+					APIDomain: "api.domain.com",
+					Lambdas: []config.APIGatewayLambda{{
+						Name: "items",
+						Routes: []config.APIGatewayRoute{
+							{Verb: "GET", Path: "/items"},
+							{Verb: "POST", Path: "/items"},
+						},
+					}},
+					Integrations: []config.APIGatewayIntegration{
+						{
+							Name:   "ordersQueue",
+							Type:   config.APIGatewayIntegrationSQS,
+							Target: "orders",
+							Routes: []config.APIGatewayRoute{{Verb: "POST", Path: "/orders"}},
+						},
+						{
+							Name:   "legacyProxy",
+							Type:   config.APIGatewayIntegrationHTTP,
+							Target: "var.legacy_api_base_url",
+							Routes: []config.APIGatewayRoute{{Path: config.APIGatewayDefaultRoute}},
+						},
+					},
+				}},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					routesEndpoint, getItems, postItems, itemsLambda, postOrders, defaultRoute, legacyAPI, ordersSQS,
+				},
+				Relationships: []resources.Relationship{
+					{Source: getItems, Target: itemsLambda},
+					{Source: routesEndpoint, Target: getItems},
+					{Source: postItems, Target: itemsLambda},
+					{Source: routesEndpoint, Target: postItems},
+					{Source: routesEndpoint, Target: postOrders},
+					{Source: routesEndpoint, Target: defaultRoute},
+					{Source: defaultRoute, Target: legacyAPI},
+					{Source: postOrders, Target: ordersSQS},
+				},
+			},
+		},
+		{
+			name: "WebSocket API",
+			fields: fields{yamlConfig: &config.Config{
+				APIGateways: []config.APIGateway{{
+					ProtocolType: config.APIGatewayProtocolWebSocket,
+					Lambdas: []config.APIGatewayLambda{
+						{Name: "connect", Path: "$connect"},
+						{Name: "chat", Path: "sendMessage"},
+					},
+				}},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{connectRoute, connectLambda, sendMessageRoute, chatLambda},
+				Relationships: []resources.Relationship{
+					{Source: connectRoute, Target: connectLambda},
+					{Source: sendMessageRoute, Target: chatLambda},
+				},
+			},
+		},
+		{
+			name: "EventBridge",
+			fields: fields{yamlConfig: &config.Config{
+				Lambdas: []config.Lambda{{Name: "processOrder"}},
+				SNSs:    []config.SNS{{Name: "order-events"}},
+				SQSs:    []config.SQS{{Name: "order-queue"}},
+				EventBridges: []config.EventBridge{
+					{
+						Name: "orders",
+						Rules: []config.EventBridgeRule{{
+							Name: "orderCreated",
+							Targets: []config.EventBridgeTarget{
+								{Type: config.EventBridgeTargetLambda, Target: "processOrder"},
+								{Type: config.EventBridgeTargetSQS, Target: "order-queue"},
+								{Type: config.EventBridgeTargetSNS, Target: "order-events"},
+								{Type: config.EventBridgeTargetStepFunctions, Target: "aws_sfn_state_machine.ship.arn"},
+							},
+						}},
+					},
+					{
+						Name: "default",
+						Rules: []config.EventBridgeRule{{
+							Name:    "instanceStateChanged",
+							Targets: []config.EventBridgeTarget{{Type: config.EventBridgeTargetLambda, Target: "processOrder"}},
+						}},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{orderLambda, orderEventsSNS, orderQueueSQS, ordersEventBus, defaultEventBus},
+				Relationships: []resources.Relationship{
+					{Source: ordersEventBus, Target: orderLambda},
+					{Source: ordersEventBus, Target: orderQueueSQS},
+					{Source: ordersEventBus, Target: orderEventsSNS},
+					{Source: defaultEventBus, Target: orderLambda},
+				},
+			},
+		},
 		{
 			name:      "when YAML is invalid or empty should return an error",
 			fields:    fields{yamlConfig: nil},